@@ -0,0 +1,257 @@
+package api
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// LabelsToIDs resolves label names to their GraphQL node IDs, for mutations
+// like createIssue/createPullRequest that take labelIds rather than names.
+func LabelsToIDs(client *Client, ghRepo Repo, names []string) ([]string, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	query := `
+	query LabelsByName($owner: String!, $repo: String!, $endCursor: String) {
+		repository(owner: $owner, name: $repo) {
+			labels(first: 100, after: $endCursor) {
+				nodes { name, id }
+				pageInfo { hasNextPage, endCursor }
+			}
+		}
+	}`
+
+	variables := map[string]interface{}{
+		"owner": ghRepo.RepoOwner(),
+		"repo":  ghRepo.RepoName(),
+	}
+
+	wanted := make(map[string]bool, len(names))
+	for _, n := range names {
+		wanted[n] = true
+	}
+	found := make(map[string]string, len(names))
+
+	for {
+		var data struct {
+			Repository struct {
+				Labels struct {
+					Nodes []struct {
+						Name string
+						ID   string
+					}
+					PageInfo struct {
+						HasNextPage bool
+						EndCursor   string
+					}
+				}
+			}
+		}
+		if err := client.GraphQL(query, variables, &data); err != nil {
+			return nil, err
+		}
+		for _, l := range data.Repository.Labels.Nodes {
+			if wanted[l.Name] {
+				found[l.Name] = l.ID
+			}
+		}
+		if len(found) == len(wanted) || !data.Repository.Labels.PageInfo.HasNextPage {
+			break
+		}
+		variables["endCursor"] = data.Repository.Labels.PageInfo.EndCursor
+	}
+
+	ids := make([]string, 0, len(names))
+	for _, n := range names {
+		id, ok := found[n]
+		if !ok {
+			return nil, fmt.Errorf("no label found with name %q", n)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// AssigneesToIDs resolves assignee logins to their GraphQL node IDs.
+func AssigneesToIDs(client *Client, logins []string) ([]string, error) {
+	ids := make([]string, 0, len(logins))
+	for _, login := range logins {
+		var data struct {
+			User struct {
+				ID string
+			}
+		}
+		err := client.GraphQL(`query UserByLogin($login: String!) { user(login: $login) { id } }`,
+			map[string]interface{}{"login": login}, &data)
+		if err != nil {
+			return nil, err
+		}
+		if data.User.ID == "" {
+			return nil, fmt.Errorf("no user found with login %q", login)
+		}
+		ids = append(ids, data.User.ID)
+	}
+	return ids, nil
+}
+
+// MilestoneToID resolves a milestone flag value (a title, or a repo-relative
+// number) to its GraphQL node ID, for mutations that take milestoneId.
+func MilestoneToID(client *Client, ghRepo Repo, milestone string) (string, error) {
+	if number, err := strconv.Atoi(milestone); err == nil {
+		return milestoneIDByNumber(client, ghRepo, number)
+	}
+	return milestoneIDByTitle(client, ghRepo, milestone)
+}
+
+func milestoneIDByNumber(client *Client, ghRepo Repo, number int) (string, error) {
+	query := `
+	query($owner: String!, $repo: String!, $number: Int!) {
+		repository(owner: $owner, name: $repo) {
+			milestone(number: $number) { id }
+		}
+	}`
+	variables := map[string]interface{}{
+		"owner":  ghRepo.RepoOwner(),
+		"repo":   ghRepo.RepoName(),
+		"number": number,
+	}
+
+	var data struct {
+		Repository struct {
+			Milestone struct{ ID string }
+		}
+	}
+	if err := client.GraphQL(query, variables, &data); err != nil {
+		return "", err
+	}
+	if data.Repository.Milestone.ID == "" {
+		return "", fmt.Errorf("no milestone found with number '%d'", number)
+	}
+	return data.Repository.Milestone.ID, nil
+}
+
+func milestoneIDByTitle(client *Client, ghRepo Repo, title string) (string, error) {
+	query := `
+	query($owner: String!, $repo: String!, $endCursor: String) {
+		repository(owner: $owner, name: $repo) {
+			milestones(first: 100, after: $endCursor) {
+				nodes { title, id }
+				pageInfo { hasNextPage, endCursor }
+			}
+		}
+	}`
+	variables := map[string]interface{}{
+		"owner": ghRepo.RepoOwner(),
+		"repo":  ghRepo.RepoName(),
+	}
+
+	for {
+		var data struct {
+			Repository struct {
+				Milestones struct {
+					Nodes []struct {
+						Title string
+						ID    string
+					}
+					PageInfo struct {
+						HasNextPage bool
+						EndCursor   string
+					}
+				}
+			}
+		}
+		if err := client.GraphQL(query, variables, &data); err != nil {
+			return "", err
+		}
+		for _, m := range data.Repository.Milestones.Nodes {
+			if m.Title == title {
+				return m.ID, nil
+			}
+		}
+		if !data.Repository.Milestones.PageInfo.HasNextPage {
+			break
+		}
+		variables["endCursor"] = data.Repository.Milestones.PageInfo.EndCursor
+	}
+	return "", fmt.Errorf("no milestone found with title %q", title)
+}
+
+// AddReviewers requests review from the given user logins on pr. GitHub
+// doesn't accept reviewers as part of createPullRequest itself, so this is a
+// follow-up mutation run right after creation.
+func AddReviewers(client *Client, pr *PullRequest, logins []string) error {
+	if len(logins) == 0 {
+		return nil
+	}
+
+	userIDs, err := AssigneesToIDs(client, logins)
+	if err != nil {
+		return err
+	}
+
+	query := `
+	mutation RequestReviews($input: RequestReviewsInput!) {
+		requestReviews(input: $input) {
+			clientMutationId
+		}
+	}`
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"pullRequestId": pr.ID,
+			"userIds":       userIDs,
+			"union":         true,
+		},
+	}
+
+	var data struct{}
+	return client.GraphQL(query, variables, &data)
+}
+
+// ProjectToID resolves a repository project board's name to its GraphQL node
+// ID, for mutations that take projectIds.
+func ProjectToID(client *Client, ghRepo Repo, name string) (string, error) {
+	query := `
+	query($owner: String!, $repo: String!, $endCursor: String) {
+		repository(owner: $owner, name: $repo) {
+			projects(first: 100, after: $endCursor) {
+				nodes { name, id }
+				pageInfo { hasNextPage, endCursor }
+			}
+		}
+	}`
+	variables := map[string]interface{}{
+		"owner": ghRepo.RepoOwner(),
+		"repo":  ghRepo.RepoName(),
+	}
+
+	for {
+		var data struct {
+			Repository struct {
+				Projects struct {
+					Nodes []struct {
+						Name string
+						ID   string
+					}
+					PageInfo struct {
+						HasNextPage bool
+						EndCursor   string
+					}
+				}
+			}
+		}
+		if err := client.GraphQL(query, variables, &data); err != nil {
+			return "", err
+		}
+		for _, p := range data.Repository.Projects.Nodes {
+			if p.Name == name {
+				return p.ID, nil
+			}
+		}
+		if !data.Repository.Projects.PageInfo.HasNextPage {
+			break
+		}
+		variables["endCursor"] = data.Repository.Projects.PageInfo.EndCursor
+	}
+	return "", fmt.Errorf("no project found with name %q", name)
+}
@@ -0,0 +1,393 @@
+package api
+
+import (
+	"fmt"
+	"time"
+)
+
+// Issue represents a GitHub issue (or, for the sake of sharing the same
+// GraphQL field machinery, the issue-shaped subset of a pull request).
+type Issue struct {
+	ID        string     `json:"id"`
+	Number    int        `json:"number"`
+	Title     string     `json:"title"`
+	URL       string     `json:"url"`
+	State     string     `json:"state"`
+	Closed    bool       `json:"closed"`
+	Body      string     `json:"body"`
+	CreatedAt time.Time  `json:"createdAt"`
+	UpdatedAt time.Time  `json:"updatedAt"`
+	ClosedAt  *time.Time `json:"closedAt"`
+
+	Author struct {
+		Login string `json:"login"`
+	} `json:"author"`
+	Assignees struct {
+		Nodes []struct {
+			Login string `json:"login"`
+		} `json:"nodes"`
+		TotalCount int `json:"totalCount"`
+	} `json:"assignees"`
+	Labels struct {
+		Nodes []struct {
+			Name string `json:"name"`
+		} `json:"nodes"`
+		TotalCount int `json:"totalCount"`
+	} `json:"labels"`
+	Milestone *struct {
+		Title string `json:"title"`
+	} `json:"milestone"`
+	Comments struct {
+		TotalCount int `json:"totalCount"`
+	} `json:"comments"`
+}
+
+// IssueOrPullRequest reports whether the given number belongs to a pull
+// request (true) or an issue (false) in ghRepo.
+func IssueOrPullRequest(requester *Requester, ghRepo Repo, number int) (isPullRequest bool, err error) {
+	type response struct {
+		Repository struct {
+			IssueOrPullRequest struct {
+				Typename string `json:"__typename"`
+			}
+		}
+	}
+
+	query := `
+	query($owner: String!, $repo: String!, $number: Int!) {
+		repository(owner: $owner, name: $repo) {
+			issueOrPullRequest(number: $number) {
+				__typename
+			}
+		}
+	}`
+
+	variables := map[string]interface{}{
+		"owner":  ghRepo.RepoOwner(),
+		"repo":   ghRepo.RepoName(),
+		"number": number,
+	}
+
+	var resp response
+	if err := requester.GraphQL(ghRepo.RepoHost(), query, variables, &resp); err != nil {
+		return false, err
+	}
+
+	return resp.Repository.IssueOrPullRequest.Typename == "PullRequest", nil
+}
+
+// CurrentLoginName returns the login of the authenticated user, used to
+// resolve "@me" shorthands passed to flags like --assignee.
+func CurrentLoginName(requester *Requester, hostname string) (string, error) {
+	var query struct {
+		Viewer struct {
+			Login string
+		}
+	}
+	if err := requester.GraphQL(hostname, `query UserCurrent { viewer { login } }`, nil, &query); err != nil {
+		return "", err
+	}
+	return query.Viewer.Login, nil
+}
+
+// IssuesAndTotalCount is the paginated result of IssueList/IssueSearch.
+type IssuesAndTotalCount struct {
+	Issues            []Issue
+	TotalCount        int
+	HasIssuesEnabled  bool
+}
+
+// IssueList fetches open (or otherwise filtered) issues for a repository,
+// narrowing the GraphQL selection set to just the requested fields so large
+// repos don't pay for data the caller won't print.
+func IssueList(requester *Requester, repo Repo, vars map[string]interface{}, limit int, fields []string) (*IssuesAndTotalCount, error) {
+	selectionSet, err := PullRequestGraphQL(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(`
+	query IssueList(
+		$owner: String!,
+		$repo: String!,
+		$limit: Int!,
+		$endCursor: String,
+		$assignee: String,
+		$author: String,
+		$mention: String,
+		$milestone: String,
+		$states: [IssueState!],
+		$labels: [String!]
+	) {
+		repository(owner: $owner, name: $repo) {
+			hasIssuesEnabled
+			issues(
+				first: $limit,
+				after: $endCursor,
+				assignee: $assignee,
+				createdBy: $author,
+				mentioned: $mention,
+				milestone: $milestone,
+				states: $states,
+				labels: $labels,
+				orderBy: {field: CREATED_AT, direction: DESC}
+			) {
+				totalCount
+				nodes { %s }
+				pageInfo {
+					hasNextPage
+					endCursor
+				}
+			}
+		}
+	}`, selectionSet)
+
+	type response struct {
+		Repository struct {
+			HasIssuesEnabled bool
+			Issues           struct {
+				TotalCount int
+				Nodes      []Issue
+				PageInfo   struct {
+					HasNextPage bool
+					EndCursor   string
+				}
+			}
+		}
+	}
+
+	variables := map[string]interface{}{
+		"owner": repo.RepoOwner(),
+		"repo":  repo.RepoName(),
+	}
+	for k, v := range vars {
+		variables[k] = v
+	}
+
+	issues := []Issue{}
+	pageLimit := limit
+	if pageLimit > 100 {
+		pageLimit = 100
+	}
+
+	var totalCount int
+	var hasIssuesEnabled bool
+	for {
+		variables["limit"] = pageLimit
+		var data response
+		if err := requester.GraphQL(repo.RepoHost(), query, variables, &data); err != nil {
+			return nil, err
+		}
+		hasIssuesEnabled = data.Repository.HasIssuesEnabled
+		if !hasIssuesEnabled {
+			return &IssuesAndTotalCount{HasIssuesEnabled: false}, nil
+		}
+
+		totalCount = data.Repository.Issues.TotalCount
+		issues = append(issues, data.Repository.Issues.Nodes...)
+		if len(issues) >= limit || !data.Repository.Issues.PageInfo.HasNextPage {
+			break
+		}
+		variables["endCursor"] = data.Repository.Issues.PageInfo.EndCursor
+		if remaining := limit - len(issues); remaining < pageLimit {
+			pageLimit = remaining
+		}
+	}
+	if len(issues) > limit {
+		issues = issues[:limit]
+	}
+
+	return &IssuesAndTotalCount{Issues: issues, TotalCount: totalCount, HasIssuesEnabled: true}, nil
+}
+
+// IssueSearch behaves like IssueList, but matches issues using GitHub's
+// search syntax instead of the issues connection's structured filters.
+func IssueSearch(requester *Requester, repo Repo, searchQuery string, limit int, fields []string) (*IssuesAndTotalCount, error) {
+	selectionSet, err := PullRequestGraphQL(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(`
+	query IssueSearch($q: String!, $limit: Int!, $endCursor: String) {
+		search(query: $q, type: ISSUE, first: $limit, after: $endCursor) {
+			issueCount
+			nodes { ... on Issue { %s } }
+			pageInfo {
+				hasNextPage
+				endCursor
+			}
+		}
+	}`, selectionSet)
+
+	type response struct {
+		Search struct {
+			IssueCount int
+			Nodes      []Issue
+			PageInfo   struct {
+				HasNextPage bool
+				EndCursor   string
+			}
+		}
+	}
+
+	variables := map[string]interface{}{"q": searchQuery}
+
+	issues := []Issue{}
+	pageLimit := limit
+	if pageLimit > 100 {
+		pageLimit = 100
+	}
+
+	var totalCount int
+	for {
+		variables["limit"] = pageLimit
+		var data response
+		if err := requester.GraphQL(repo.RepoHost(), query, variables, &data); err != nil {
+			return nil, err
+		}
+		totalCount = data.Search.IssueCount
+		issues = append(issues, data.Search.Nodes...)
+		if len(issues) >= limit || !data.Search.PageInfo.HasNextPage {
+			break
+		}
+		variables["endCursor"] = data.Search.PageInfo.EndCursor
+		if remaining := limit - len(issues); remaining < pageLimit {
+			pageLimit = remaining
+		}
+	}
+	if len(issues) > limit {
+		issues = issues[:limit]
+	}
+
+	return &IssuesAndTotalCount{Issues: issues, TotalCount: totalCount, HasIssuesEnabled: true}, nil
+}
+
+// RepositoryMilestoneList resolves a milestone title to its GraphQL node ID.
+func RepositoryMilestoneList(requester *Requester, repo Repo, title string) (string, error) {
+	query := `
+	query RepositoryMilestoneList($owner: String!, $repo: String!, $endCursor: String) {
+		repository(owner: $owner, name: $repo) {
+			milestones(first: 100, after: $endCursor) {
+				nodes { title, id }
+				pageInfo {
+					hasNextPage
+					endCursor
+				}
+			}
+		}
+	}`
+
+	variables := map[string]interface{}{
+		"owner": repo.RepoOwner(),
+		"repo":  repo.RepoName(),
+	}
+
+	type response struct {
+		Repository struct {
+			Milestones struct {
+				Nodes []struct {
+					Title string
+					ID    string
+				}
+				PageInfo struct {
+					HasNextPage bool
+					EndCursor   string
+				}
+			}
+		}
+	}
+
+	for {
+		var data response
+		if err := requester.GraphQL(repo.RepoHost(), query, variables, &data); err != nil {
+			return "", err
+		}
+		for _, m := range data.Repository.Milestones.Nodes {
+			if m.Title == title {
+				return m.ID, nil
+			}
+		}
+		if !data.Repository.Milestones.PageInfo.HasNextPage {
+			break
+		}
+		variables["endCursor"] = data.Repository.Milestones.PageInfo.EndCursor
+	}
+
+	return "", fmt.Errorf("no milestone found with title %q", title)
+}
+
+// RepositoryMilestoneByNumber resolves a milestone's repo-relative number to
+// its GraphQL node ID.
+func RepositoryMilestoneByNumber(requester *Requester, repo Repo, number int) (string, error) {
+	query := `
+	query RepositoryMilestoneByNumber($owner: String!, $repo: String!, $number: Int!) {
+		repository(owner: $owner, name: $repo) {
+			milestone(number: $number) {
+				id
+			}
+		}
+	}`
+
+	variables := map[string]interface{}{
+		"owner":  repo.RepoOwner(),
+		"repo":   repo.RepoName(),
+		"number": number,
+	}
+
+	type response struct {
+		Repository struct {
+			Milestone struct {
+				ID string
+			}
+		}
+	}
+
+	var data response
+	if err := requester.GraphQL(repo.RepoHost(), query, variables, &data); err != nil {
+		return "", err
+	}
+	if data.Repository.Milestone.ID == "" {
+		return "", fmt.Errorf("no milestone found with number '%d'", number)
+	}
+	return data.Repository.Milestone.ID, nil
+}
+
+// IssueCreate submits a new issue for baseRepo.
+func IssueCreate(client *Client, repo Repo, params map[string]interface{}) (*Issue, error) {
+	repoID, err := GitHubRepoId(client, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+	mutation CreateIssue($input: CreateIssueInput!) {
+		createIssue(input: $input) {
+			issue {
+				url
+				number
+			}
+		}
+	}`
+
+	inputParams := map[string]interface{}{
+		"repositoryId": repoID,
+	}
+	for key, val := range params {
+		inputParams[key] = val
+	}
+	variables := map[string]interface{}{
+		"input": inputParams,
+	}
+
+	result := struct {
+		CreateIssue struct {
+			Issue Issue
+		}
+	}{}
+	if err := client.GraphQL(query, variables, &result); err != nil {
+		return nil, err
+	}
+
+	return &result.CreateIssue.Issue, nil
+}
@@ -1,9 +1,18 @@
 package api
 
 import (
+	"context"
 	"fmt"
 )
 
+// defaultPRQueryRetries is how many additional attempts the PR query/mutation
+// functions in this file make, via Client.NewRequest's Retries, before
+// giving up on a transient failure. Request.Do classifies each failure
+// before retrying, so this only costs extra round trips on errors worth
+// retrying (5xx, rate limiting, a transient GraphQL error type) — a
+// permanent one (bad credentials, an unknown repo) returns immediately.
+const defaultPRQueryRetries = 2
+
 type PullRequestsPayload struct {
 	ViewerCreated   []PullRequest
 	ReviewRequested []PullRequest
@@ -11,25 +20,26 @@ type PullRequestsPayload struct {
 }
 
 type PullRequest struct {
-	Number      int
-	Title       string
-	State       string
-	URL         string
-	HeadRefName string
+	ID          string `json:"id"`
+	Number      int    `json:"number"`
+	Title       string `json:"title"`
+	State       string `json:"state"`
+	URL         string `json:"url"`
+	HeadRefName string `json:"headRefName"`
 
 	HeadRepositoryOwner struct {
-		Login string
-	}
+		Login string `json:"login"`
+	} `json:"headRepositoryOwner"`
 	HeadRepository struct {
-		Name             string
+		Name             string `json:"name"`
 		DefaultBranchRef struct {
-			Name string
-		}
-	}
-	IsCrossRepository   bool
-	MaintainerCanModify bool
+			Name string `json:"name"`
+		} `json:"defaultBranchRef"`
+	} `json:"headRepository"`
+	IsCrossRepository   bool `json:"isCrossRepository"`
+	MaintainerCanModify bool `json:"maintainerCanModify"`
 
-	ReviewDecision string
+	ReviewDecision string `json:"reviewDecision"`
 
 	Commits struct {
 		Nodes []struct {
@@ -37,15 +47,19 @@ type PullRequest struct {
 				StatusCheckRollup struct {
 					Contexts struct {
 						Nodes []struct {
-							State      string
-							Status     string
-							Conclusion string
-						}
-					}
-				}
-			}
-		}
-	}
+							Name       string `json:"name"`
+							Context    string `json:"context"`
+							State      string `json:"state"`
+							Status     string `json:"status"`
+							Conclusion string `json:"conclusion"`
+							TargetUrl  string `json:"targetUrl"`
+							DetailsUrl string `json:"detailsUrl"`
+						} `json:"nodes"`
+					} `json:"contexts"`
+				} `json:"statusCheckRollup"`
+			} `json:"commit"`
+		} `json:"nodes"`
+	} `json:"commits"`
 }
 
 func (pr PullRequest) HeadLabel() string {
@@ -74,6 +88,42 @@ func (pr *PullRequest) ReviewStatus() PullRequestReviewStatus {
 	return status
 }
 
+// Check is a single commit status or check run reported against a pull
+// request's head commit.
+type Check struct {
+	Name      string `json:"name"`
+	State     string `json:"state"`
+	TargetURL string `json:"targetUrl"`
+}
+
+// Checks returns the per-check detail backing ChecksStatus, for commands
+// that need to list individual check contexts rather than just the summary.
+func (pr *PullRequest) Checks() []Check {
+	if len(pr.Commits.Nodes) == 0 {
+		return nil
+	}
+
+	commit := pr.Commits.Nodes[0].Commit
+	checks := make([]Check, 0, len(commit.StatusCheckRollup.Contexts.Nodes))
+	for _, c := range commit.StatusCheckRollup.Contexts.Nodes {
+		name := c.Context
+		state := c.State
+		targetURL := c.TargetUrl
+		if state == "" {
+			// CheckRun
+			name = c.Name
+			targetURL = c.DetailsUrl
+			if c.Status == "COMPLETED" {
+				state = c.Conclusion
+			} else {
+				state = c.Status
+			}
+		}
+		checks = append(checks, Check{Name: name, State: state, TargetURL: targetURL})
+	}
+	return checks
+}
+
 type PullRequestChecksStatus struct {
 	Pending int
 	Failing int
@@ -114,9 +164,10 @@ func (pr *PullRequest) ChecksStatus() (summary PullRequestChecksStatus) {
 type Repo interface {
 	RepoName() string
 	RepoOwner() string
+	RepoHost() string
 }
 
-func PullRequests(client *Client, ghRepo Repo, currentBranch, currentUsername string) (*PullRequestsPayload, error) {
+func PullRequests(ctx context.Context, client *Client, ghRepo Repo, currentBranch, currentUsername string) (*PullRequestsPayload, error) {
 	type edges struct {
 		Edges []struct {
 			Node PullRequest
@@ -153,11 +204,15 @@ func PullRequests(client *Client, ghRepo Repo, currentBranch, currentUsername st
 						contexts(last: 100) {
 							nodes {
 								...on StatusContext {
+									context
 									state
+									targetUrl
 								}
 								...on CheckRun {
+									name
 									status
 									conclusion
+									detailsUrl
 								}
 							}
 						}
@@ -218,7 +273,7 @@ func PullRequests(client *Client, ghRepo Repo, currentBranch, currentUsername st
 	}
 
 	var resp response
-	err := client.GraphQL(query, variables, &resp)
+	err := client.NewRequest(ghRepo.RepoHost()).GraphQL(query, variables).Into(&resp).Retries(defaultPRQueryRetries).Do(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -247,7 +302,7 @@ func PullRequests(client *Client, ghRepo Repo, currentBranch, currentUsername st
 	return &payload, nil
 }
 
-func PullRequestByNumber(client *Client, ghRepo Repo, number int) (*PullRequest, error) {
+func PullRequestByNumber(ctx context.Context, client *Client, ghRepo Repo, number int) (*PullRequest, error) {
 	type response struct {
 		Repository struct {
 			PullRequest PullRequest
@@ -270,6 +325,29 @@ func PullRequestByNumber(client *Client, ghRepo Repo, number int) (*PullRequest,
 				}
 				isCrossRepository
 				maintainerCanModify
+				commits(last: 1) {
+					nodes {
+						commit {
+							statusCheckRollup {
+								contexts(last: 100) {
+									nodes {
+										...on StatusContext {
+											context
+											state
+											targetUrl
+										}
+										...on CheckRun {
+											name
+											status
+											conclusion
+											detailsUrl
+										}
+									}
+								}
+							}
+						}
+					}
+				}
 			}
 		}
 	}`
@@ -281,7 +359,7 @@ func PullRequestByNumber(client *Client, ghRepo Repo, number int) (*PullRequest,
 	}
 
 	var resp response
-	err := client.GraphQL(query, variables, &resp)
+	err := client.NewRequest(ghRepo.RepoHost()).GraphQL(query, variables).Into(&resp).Retries(defaultPRQueryRetries).Do(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -335,7 +413,7 @@ func PullRequestsForBranch(client *Client, ghRepo Repo, branch string) ([]PullRe
 	return prs, nil
 }
 
-func CreatePullRequest(client *Client, ghRepo Repo, params map[string]interface{}) (*PullRequest, error) {
+func CreatePullRequest(ctx context.Context, client *Client, ghRepo Repo, params map[string]interface{}) (*PullRequest, error) {
 	repoID, err := GitHubRepoId(client, ghRepo)
 	if err != nil {
 		return nil, err
@@ -345,6 +423,8 @@ func CreatePullRequest(client *Client, ghRepo Repo, params map[string]interface{
 		mutation CreatePullRequest($input: CreatePullRequestInput!) {
 			createPullRequest(input: $input) {
 				pullRequest {
+					id
+					number
 					url
 				}
 			}
@@ -366,7 +446,7 @@ func CreatePullRequest(client *Client, ghRepo Repo, params map[string]interface{
 		}
 	}{}
 
-	err = client.GraphQL(query, variables, &result)
+	err = client.NewRequest(ghRepo.RepoHost()).GraphQL(query, variables).Into(&result).Retries(defaultPRQueryRetries).Do(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -374,7 +454,7 @@ func CreatePullRequest(client *Client, ghRepo Repo, params map[string]interface{
 	return &result.CreatePullRequest.PullRequest, nil
 }
 
-func PullRequestList(client *Client, vars map[string]interface{}, limit int) ([]PullRequest, error) {
+func PullRequestList(ctx context.Context, client *Client, vars map[string]interface{}, limit int) ([]PullRequest, error) {
 	type prBlock struct {
 		Edges []struct {
 			Node PullRequest
@@ -485,7 +565,7 @@ func PullRequestList(client *Client, vars map[string]interface{}, limit int) ([]
 	for {
 		variables["limit"] = pageLimit
 		var data response
-		err := client.GraphQL(query, variables, &data)
+		err := client.NewRequest("").GraphQL(query, variables).Into(&data).Retries(defaultPRQueryRetries).Do(ctx)
 		if err != nil {
 			return nil, err
 		}
@@ -1,7 +1,9 @@
 package api
 
 import (
+	"fmt"
 	"strings"
+	"sync"
 )
 
 func squeeze(r rune) rune {
@@ -115,10 +117,89 @@ var PullRequestFields = append(IssueFields,
 	"statusCheckRollup",
 )
 
-func PullRequestGraphQL(fields []string) string {
+// allowedPullRequestFields is the set of field names PullRequestGraphQL will
+// render into a query; anything else (e.g. a mistyped value from a --json
+// flag) is rejected rather than interpolated verbatim into the GraphQL
+// selection set.
+var allowedPullRequestFields = func() map[string]bool {
+	allowed := make(map[string]bool, len(PullRequestFields))
+	for _, f := range PullRequestFields {
+		allowed[f] = true
+	}
+	return allowed
+}()
+
+// FieldSpec describes one field to render into a PullRequestGraphQL
+// selection set. Selection overrides the field's built-in sub-selection
+// (e.g. to request fewer comments than the default last:100), letting a
+// caller that only needs a count ask for "comments(last:10){totalCount}"
+// instead of paying for the full comment bodies.
+type FieldSpec struct {
+	Name      string
+	Selection string
+}
+
+// pullRequestGraphQLCache memoizes PullRequestGraphQL's output by its joined
+// field list, so repeat calls with the same fields (the common case:
+// paginating the same list query) skip rebuilding the selection set.
+var pullRequestGraphQLCache sync.Map
+
+// PullRequestGraphQL renders fields into a GraphQL selection set for an
+// Issue/PullRequest object, returning an error instead of a malformed query
+// if fields contains a name buildPullRequestGraphQL doesn't know how to
+// render.
+func PullRequestGraphQL(fields []string) (string, error) {
+	specs := make([]FieldSpec, len(fields))
+	for i, field := range fields {
+		specs[i] = FieldSpec{Name: field}
+	}
+	return PullRequestGraphQLWithOverrides(specs)
+}
+
+// PullRequestGraphQLWithOverrides behaves like PullRequestGraphQL, but lets
+// the caller replace individual fields' default sub-selection via
+// FieldSpec.Selection.
+func PullRequestGraphQLWithOverrides(specs []FieldSpec) (string, error) {
+	names := make([]string, len(specs))
+	for i, spec := range specs {
+		if !allowedPullRequestFields[spec.Name] {
+			return "", fmt.Errorf("unknown GraphQL field: %q", spec.Name)
+		}
+		names[i] = spec.Name
+	}
+
+	key := buildPullRequestGraphQLCacheKey(specs)
+	if cached, ok := pullRequestGraphQLCache.Load(key); ok {
+		return cached.(string), nil
+	}
+
+	query := buildPullRequestGraphQL(specs)
+	pullRequestGraphQLCache.Store(key, query)
+	return query, nil
+}
+
+// buildPullRequestGraphQLCacheKey joins each spec's name and override into a
+// single string unambiguous enough to use as a cache key.
+func buildPullRequestGraphQLCacheKey(specs []FieldSpec) string {
+	parts := make([]string, len(specs))
+	for i, spec := range specs {
+		parts[i] = spec.Name + "=" + spec.Selection
+	}
+	return strings.Join(parts, ",")
+}
+
+// buildPullRequestGraphQL renders the already-validated field specs into a
+// GraphQL selection set, expanding the fields that need more than their bare
+// name (nested connections, fragments, aliases) unless the caller supplied
+// its own override.
+func buildPullRequestGraphQL(specs []FieldSpec) string {
 	var q []string
-	for _, field := range fields {
-		switch field {
+	for _, spec := range specs {
+		if spec.Selection != "" {
+			q = append(q, spec.Selection)
+			continue
+		}
+		switch spec.Name {
 		case "author":
 			q = append(q, `author{login}`)
 		case "headRepositoryOwner":
@@ -142,7 +223,7 @@ func PullRequestGraphQL(fields []string) string {
 		case "statusCheckRollup":
 			q = append(q, prStatusCheckRollup)
 		default:
-			q = append(q, field)
+			q = append(q, spec.Name)
 		}
 	}
 	return strings.Join(q, ",")
@@ -0,0 +1,333 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/github/gh-cli/internal/ghinstance"
+)
+
+// ErrorCount tracks how many requests Requester.GraphQL has given up on
+// (after exhausting MaxRetries or hitting a permanent failure), across every
+// Requester in the process. It's a coarse signal for callers that want to
+// surface "the API seems to be having trouble" without plumbing per-call
+// telemetry through every query function.
+var ErrorCount int64
+
+// Middleware wraps a RoundTripFunc with additional behavior (logging,
+// metrics, auth headers, ...), composed around the Requester's transport.
+type Middleware func(RoundTripFunc) RoundTripFunc
+
+// RoundTripFunc performs a single HTTP round trip.
+type RoundTripFunc func(*http.Request) (*http.Response, error)
+
+// Requester issues GraphQL requests with retry/backoff, a per-request
+// timeout, and GraphQL error classification, unlike Client.GraphQL which
+// issues a single bare POST and surfaces whatever comes back. It exists
+// alongside Client rather than replacing it so callers can migrate one
+// query at a time.
+type Requester struct {
+	httpClient *http.Client
+	transport  RoundTripFunc
+
+	// Timeout bounds each individual attempt, not the overall retry loop.
+	Timeout time.Duration
+	// MaxRetries is the number of retries after the first attempt.
+	MaxRetries int
+}
+
+// NewRequester builds a Requester over httpClient, applying any middleware
+// in the order given (the first wraps outermost).
+func NewRequester(httpClient *http.Client, middleware ...Middleware) *Requester {
+	r := &Requester{
+		httpClient: httpClient,
+		Timeout:    30 * time.Second,
+		MaxRetries: 3,
+	}
+
+	transport := RoundTripFunc(httpClient.Do)
+	for i := len(middleware) - 1; i >= 0; i-- {
+		transport = middleware[i](transport)
+	}
+	r.transport = transport
+
+	return r
+}
+
+// WithRetry overrides MaxRetries, returning r for chaining, e.g.
+// api.NewRequester(httpClient).WithRetry(5).
+func (r *Requester) WithRetry(max int) *Requester {
+	r.MaxRetries = max
+	return r
+}
+
+// GraphQLError is a single error reported by the GraphQL endpoint, exported
+// so callers can use errors.As to branch on Type (e.g. to special-case
+// NOT_FOUND) instead of matching on Error()'s message text. Path locates the
+// error within the query's selection set, mirroring the GraphQL spec.
+type GraphQLError struct {
+	Type    string        `json:"type"`
+	Message string        `json:"message"`
+	Path    []interface{} `json:"path"`
+}
+
+func (e GraphQLError) Error() string {
+	return e.Message
+}
+
+// RateLimitError indicates GraphQL gave up on a request after exhausting
+// MaxRetries while being secondary-rate-limited (a 403 with Retry-After or
+// X-RateLimit-Reset set), so callers can distinguish it from an ordinary
+// transient failure and decide whether to back off longer themselves.
+type RateLimitError struct {
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("rate limited, retry after %s", e.RetryAfter)
+}
+
+// AbuseError indicates a 403 response whose body names GitHub's abuse
+// detection mechanism specifically, rather than an ordinary permission
+// failure. Unlike a plain 403 (see permanentError), it's transient and
+// worth retrying.
+type AbuseError struct {
+	Message string
+}
+
+func (e *AbuseError) Error() string { return e.Message }
+
+// NetworkError wraps a transport-level failure (DNS, TLS, connection
+// refused, ...) that happened before any response was received, so callers
+// can tell "the server said no" apart from "we couldn't reach it".
+type NetworkError struct {
+	err error
+}
+
+func (e *NetworkError) Error() string { return fmt.Sprintf("network error: %s", e.err) }
+func (e *NetworkError) Unwrap() error { return e.err }
+
+// transientGraphQLErrorTypes lists the GraphQL "type" values worth retrying;
+// anything else (NOT_FOUND, FORBIDDEN, ...) is treated as fatal.
+var transientGraphQLErrorTypes = map[string]bool{
+	"RATE_LIMITED":        true,
+	"TIMEOUT":             true,
+	"SERVICE_UNAVAILABLE": true,
+}
+
+// HTTPError is assumed to be Client.REST/Client.GraphQL's error type for a
+// non-2xx response (StatusCode, Message, ...); classifyError only reads its
+// StatusCode field.
+
+// classifyError turns an error returned by Client.GraphQL/Client.REST (via
+// Request.doOnce) into the same RateLimitError/AbuseError/NetworkError/
+// permanentError hierarchy Requester.GraphQL produces, so a Client-backed
+// Request gets the same retry-vs-fail-fast treatment and callers can
+// errors.As against one set of types regardless of which path they went
+// through. Errors it doesn't recognize are returned unchanged and keep
+// retrying exactly as before.
+func classifyError(err error) error {
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		msg := strings.TrimSpace(httpErr.Message)
+		switch {
+		case httpErr.StatusCode == http.StatusForbidden && strings.Contains(strings.ToLower(msg), "abuse detection"):
+			return &AbuseError{Message: msg}
+		case httpErr.StatusCode == http.StatusForbidden || httpErr.StatusCode == http.StatusTooManyRequests:
+			return &RateLimitError{}
+		case httpErr.StatusCode == http.StatusBadGateway || httpErr.StatusCode == http.StatusServiceUnavailable:
+			return err
+		default:
+			return &permanentError{err}
+		}
+	}
+
+	var gqlErr GraphQLError
+	if errors.As(err, &gqlErr) {
+		if transientGraphQLErrorTypes[gqlErr.Type] {
+			return err
+		}
+		return &permanentError{err}
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return &NetworkError{err}
+	}
+
+	return err
+}
+
+// permanentError marks a doOnce failure as not worth retrying, so GraphQL's
+// retry loop can surface it immediately instead of burning MaxRetries.
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []GraphQLError  `json:"errors"`
+}
+
+// GraphQL executes query against hostname's GraphQL endpoint, retrying
+// transient failures (502/503, secondary rate limits, transient GraphQL
+// errors) with exponential backoff and jitter, honoring the Retry-After and
+// X-RateLimit-Reset response headers when present.
+func (r *Requester) GraphQL(hostname, query string, variables map[string]interface{}, data interface{}) error {
+	reqBody, err := json.Marshal(map[string]interface{}{
+		"query":     query,
+		"variables": variables,
+	})
+	if err != nil {
+		return err
+	}
+
+	endpoint := ghinstance.GraphQLEndpoint(hostname)
+
+	var lastErr error
+	for attempt := 0; attempt <= r.MaxRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(r.backoff(attempt, lastErr))
+		}
+
+		gr, retryAfter, err := r.doOnce(endpoint, reqBody)
+		if err != nil {
+			var permErr *permanentError
+			if errors.As(err, &permErr) {
+				atomic.AddInt64(&ErrorCount, 1)
+				return permErr.err
+			}
+			lastErr = err
+			continue
+		}
+		if retryAfter > 0 {
+			lastErr = &RateLimitError{RetryAfter: retryAfter}
+			time.Sleep(retryAfter)
+			continue
+		}
+
+		if len(gr.Errors) > 0 {
+			first := gr.Errors[0]
+			if transientGraphQLErrorTypes[first.Type] {
+				lastErr = first
+				continue
+			}
+			atomic.AddInt64(&ErrorCount, 1)
+			return first
+		}
+
+		if data == nil {
+			return nil
+		}
+		return json.Unmarshal(gr.Data, data)
+	}
+
+	if lastErr != nil {
+		atomic.AddInt64(&ErrorCount, 1)
+	}
+	return lastErr
+}
+
+// doOnce performs a single attempt, returning either a parsed GraphQL
+// response, a non-zero retryAfter duration for a rate-limited response, or
+// an error for anything else worth retrying.
+func (r *Requester) doOnce(endpoint string, reqBody []byte) (*graphQLResponse, time.Duration, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.transport(req)
+	if err != nil {
+		return nil, 0, &NetworkError{err}
+	}
+	defer resp.Body.Close()
+
+	if retryAfter := rateLimitRetryAfter(resp); retryAfter > 0 {
+		return nil, retryAfter, nil
+	}
+
+	if resp.StatusCode == http.StatusForbidden {
+		body, _ := ioutil.ReadAll(resp.Body)
+		msg := strings.TrimSpace(string(body))
+		if strings.Contains(strings.ToLower(msg), "abuse detection") {
+			// Abuse-rate-limiting is transient, unlike an ordinary 403, so
+			// it's worth retrying rather than failing fast.
+			return nil, 0, &AbuseError{Message: msg}
+		}
+		// A 403 with neither Retry-After nor X-RateLimit-Reset, and no
+		// abuse-detection message, isn't a transient rate limit; it's a
+		// permanent auth/permission failure (bad credentials, an org's IP
+		// allow list, ...), so retrying it would just burn MaxRetries before
+		// surfacing a confusing "unable to parse response" once the body
+		// fails to unmarshal as GraphQL.
+		return nil, 0, &permanentError{fmt.Errorf("request forbidden (HTTP 403): %s", msg)}
+	}
+
+	if resp.StatusCode == http.StatusBadGateway || resp.StatusCode == http.StatusServiceUnavailable {
+		return nil, 0, fmt.Errorf("server error: %s", resp.Status)
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var gr graphQLResponse
+	if err := json.Unmarshal(body, &gr); err != nil {
+		return nil, 0, fmt.Errorf("unable to parse response: %w", err)
+	}
+	return &gr, 0, nil
+}
+
+// rateLimitRetryAfter reports how long to wait before retrying a secondary
+// rate-limit response, preferring the Retry-After header and falling back to
+// X-RateLimit-Reset. It returns zero for anything else.
+func rateLimitRetryAfter(resp *http.Response) time.Duration {
+	if resp.StatusCode != http.StatusForbidden {
+		return 0
+	}
+
+	if s := resp.Header.Get("Retry-After"); s != "" {
+		if secs, err := strconv.Atoi(s); err == nil {
+			return time.Duration(secs) * time.Second
+		}
+	}
+
+	if s := resp.Header.Get("X-RateLimit-Reset"); s != "" {
+		if epoch, err := strconv.ParseInt(s, 10, 64); err == nil {
+			if d := time.Until(time.Unix(epoch, 0)); d > 0 {
+				return d
+			}
+		}
+	}
+
+	return 0
+}
+
+// backoff computes an exponentially increasing delay with jitter for the
+// given attempt number (1-indexed).
+func (r *Requester) backoff(attempt int, lastErr error) time.Duration {
+	base := time.Duration(math.Pow(2, float64(attempt))) * 100 * time.Millisecond
+	jitter := time.Duration(rand.Int63n(int64(base) / 2))
+	return base + jitter
+}
@@ -0,0 +1,173 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/github/gh-cli/pkg/httpmock"
+)
+
+func TestRequester_GraphQL_retriesOn502(t *testing.T) {
+	reg := &httpmock.Registry{}
+	defer reg.Verify(t)
+
+	reg.Register(httpmock.GraphQL(`query`), httpmock.StatusStringResponse(502, "bad gateway"))
+	reg.Register(httpmock.GraphQL(`query`), httpmock.StringResponse(`{"data":{"viewer":{"login":"monalisa"}}}`))
+
+	httpClient := &http.Client{Transport: reg}
+	requester := NewRequester(httpClient)
+	requester.MaxRetries = 1
+
+	var resp struct {
+		Viewer struct {
+			Login string
+		}
+	}
+	if err := requester.GraphQL("github.com", `query { viewer { login } }`, nil, &resp); err != nil {
+		t.Fatalf("GraphQL() returned error: %v", err)
+	}
+	if resp.Viewer.Login != "monalisa" {
+		t.Errorf("expected login monalisa, got %q", resp.Viewer.Login)
+	}
+}
+
+func TestRequester_GraphQL_backsOffOnRateLimit(t *testing.T) {
+	reg := &httpmock.Registry{}
+	defer reg.Verify(t)
+
+	rateLimited := httpmock.StatusStringResponse(403, "rate limited")
+	rateLimited.Header = http.Header{"Retry-After": []string{"0"}}
+	reg.Register(httpmock.GraphQL(`query`), rateLimited)
+	reg.Register(httpmock.GraphQL(`query`), httpmock.StringResponse(`{"data":{"viewer":{"login":"monalisa"}}}`))
+
+	httpClient := &http.Client{Transport: reg}
+	requester := NewRequester(httpClient)
+	requester.MaxRetries = 1
+
+	var resp struct {
+		Viewer struct {
+			Login string
+		}
+	}
+	if err := requester.GraphQL("github.com", `query { viewer { login } }`, nil, &resp); err != nil {
+		t.Fatalf("GraphQL() returned error: %v", err)
+	}
+	if resp.Viewer.Login != "monalisa" {
+		t.Errorf("expected login monalisa, got %q", resp.Viewer.Login)
+	}
+}
+
+func TestRequester_GraphQL_plain403FailsFastWithoutRetry(t *testing.T) {
+	reg := &httpmock.Registry{}
+	defer reg.Verify(t)
+
+	reg.Register(httpmock.GraphQL(`query`), httpmock.StatusStringResponse(403, "Bad credentials"))
+
+	httpClient := &http.Client{Transport: reg}
+	requester := NewRequester(httpClient)
+	requester.MaxRetries = 2
+
+	err := requester.GraphQL("github.com", `query { viewer { login } }`, nil, nil)
+	if err == nil || !strings.Contains(err.Error(), "forbidden") {
+		t.Fatalf("expected a forbidden error without retries, got %v", err)
+	}
+}
+
+func TestRequester_GraphQL_abuseDetectionRetries(t *testing.T) {
+	reg := &httpmock.Registry{}
+	defer reg.Verify(t)
+
+	reg.Register(httpmock.GraphQL(`query`), httpmock.StatusStringResponse(403, "You have triggered an abuse detection mechanism"))
+	reg.Register(httpmock.GraphQL(`query`), httpmock.StringResponse(`{"data":{"viewer":{"login":"monalisa"}}}`))
+
+	httpClient := &http.Client{Transport: reg}
+	requester := NewRequester(httpClient)
+	requester.MaxRetries = 1
+
+	var resp struct {
+		Viewer struct {
+			Login string
+		}
+	}
+	if err := requester.GraphQL("github.com", `query { viewer { login } }`, nil, &resp); err != nil {
+		t.Fatalf("GraphQL() returned error: %v", err)
+	}
+	if resp.Viewer.Login != "monalisa" {
+		t.Errorf("expected login monalisa, got %q", resp.Viewer.Login)
+	}
+}
+
+func TestRequester_GraphQL_rateLimitErrorSurfacedAfterRetries(t *testing.T) {
+	reg := &httpmock.Registry{}
+	defer reg.Verify(t)
+
+	rateLimited := httpmock.StatusStringResponse(403, "rate limited")
+	rateLimited.Header = http.Header{"Retry-After": []string{"0"}}
+	reg.Register(httpmock.GraphQL(`query`), rateLimited)
+	reg.Register(httpmock.GraphQL(`query`), rateLimited)
+
+	httpClient := &http.Client{Transport: reg}
+	requester := NewRequester(httpClient)
+	requester.MaxRetries = 1
+
+	err := requester.GraphQL("github.com", `query { viewer { login } }`, nil, nil)
+	var rateLimitErr *RateLimitError
+	if !errors.As(err, &rateLimitErr) {
+		t.Fatalf("expected a *RateLimitError, got %v (%T)", err, err)
+	}
+}
+
+func TestRequester_GraphQL_networkErrorDoesNotPanic(t *testing.T) {
+	httpClient := &http.Client{Transport: roundTripFunc(func(*http.Request) (*http.Response, error) {
+		return nil, errors.New("connection refused")
+	})}
+	requester := NewRequester(httpClient)
+	requester.MaxRetries = 0
+
+	err := requester.GraphQL("github.com", `query { viewer { login } }`, nil, nil)
+	var netErr *NetworkError
+	if !errors.As(err, &netErr) {
+		t.Fatalf("expected a *NetworkError, got %v (%T)", err, err)
+	}
+}
+
+func TestRequester_GraphQL_incrementsErrorCount(t *testing.T) {
+	reg := &httpmock.Registry{}
+	defer reg.Verify(t)
+	reg.Register(httpmock.GraphQL(`query`), httpmock.StatusStringResponse(403, "Bad credentials"))
+
+	httpClient := &http.Client{Transport: reg}
+	requester := NewRequester(httpClient)
+	requester.MaxRetries = 0
+
+	before := atomic.LoadInt64(&ErrorCount)
+	if err := requester.GraphQL("github.com", `query { viewer { login } }`, nil, nil); err == nil {
+		t.Fatalf("expected an error")
+	}
+	if after := atomic.LoadInt64(&ErrorCount); after != before+1 {
+		t.Errorf("expected ErrorCount to increment by 1, went from %d to %d", before, after)
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestRequester_GraphQL_fatalErrorDoesNotRetry(t *testing.T) {
+	reg := &httpmock.Registry{}
+	defer reg.Verify(t)
+
+	reg.Register(httpmock.GraphQL(`query`), httpmock.StringResponse(`{"errors":[{"type":"NOT_FOUND","message":"no such repo"}]}`))
+
+	httpClient := &http.Client{Transport: reg}
+	requester := NewRequester(httpClient)
+	requester.MaxRetries = 2
+
+	err := requester.GraphQL("github.com", `query { viewer { login } }`, nil, nil)
+	if err == nil || err.Error() != "no such repo" {
+		t.Fatalf("expected fatal 'no such repo' error, got %v", err)
+	}
+}
@@ -4,7 +4,12 @@ import (
 	"bufio"
 	"context"
 	"fmt"
+	"io"
 	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/github/ghcs/api"
 	"github.com/github/ghcs/internal/codespaces"
@@ -12,7 +17,12 @@ import (
 )
 
 func NewLogsCmd() *cobra.Command {
-	return &cobra.Command{
+	var follow bool
+	var since time.Duration
+	var postCreate bool
+	var tailLines int
+
+	cmd := &cobra.Command{
 		Use:   "logs",
 		Short: "Access Codespace logs",
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -20,18 +30,43 @@ func NewLogsCmd() *cobra.Command {
 			if len(args) > 0 {
 				codespaceName = args[0]
 			}
-			return Logs(codespaceName)
+			return Logs(codespaceName, logsOptions{
+				follow:     follow,
+				since:      since,
+				postCreate: postCreate,
+				tailLines:  tailLines,
+			})
 		},
 	}
+
+	cmd.Flags().BoolVarP(&follow, "follow", "f", false, "Stream new log lines as they are written")
+	cmd.Flags().DurationVar(&since, "since", 0, "Only show log lines newer than a relative duration, e.g. 10m")
+	cmd.Flags().BoolVar(&postCreate, "post-create", false, "Print postCreateCommand step transitions alongside the raw log")
+	cmd.Flags().IntVarP(&tailLines, "lines", "n", 100, "Number of lines to start tailing from")
+
+	return cmd
 }
 
 func init() {
 	rootCmd.AddCommand(NewLogsCmd())
 }
 
-func Logs(codespaceName string) error {
+const creationLogPath = "/workspaces/.codespaces/.persistedshare/creation.log"
+
+type logsOptions struct {
+	follow     bool
+	since      time.Duration
+	postCreate bool
+	tailLines  int
+}
+
+// runRemoteCommand is a seam for substituting a fake in tests.
+var runRemoteCommand func(ctx context.Context, tunnelPort int, dst, command string) (io.ReadCloser, error) = codespaces.RunCommand
+
+func Logs(codespaceName string, opts logsOptions) error {
 	apiClient := api.New(os.Getenv("GITHUB_TOKEN"))
-	ctx := context.Background()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 
 	user, err := apiClient.GetUser(ctx)
 	if err != nil {
@@ -43,7 +78,8 @@ func Logs(codespaceName string) error {
 		return fmt.Errorf("get or choose codespace: %v", err)
 	}
 
-	lsclient, err := codespaces.ConnectToLiveshare(ctx, apiClient, token, codespace)
+	log := rootLogger()
+	lsclient, err := codespaces.ConnectToLiveshare(ctx, log, apiClient, user.Login, token, codespace)
 	if err != nil {
 		return fmt.Errorf("connecting to liveshare: %v", err)
 	}
@@ -54,42 +90,96 @@ func Logs(codespaceName string) error {
 	}
 
 	dst := fmt.Sprintf("%s@localhost", getSSHUser(codespace))
-	stdout, err := codespaces.RunCommand(
-		ctx, tunnelPort, dst, "cat /workspaces/.codespaces/.persistedshare/creation.log",
-	)
+	stdout, err := runRemoteCommand(ctx, tunnelPort, dst, logsCommand(opts))
 	if err != nil {
 		return fmt.Errorf("run command: %v", err)
 	}
 
-	done := make(chan error)
-	go func() {
-		scanner := bufio.NewScanner(stdout)
-		for scanner.Scan() {
-			fmt.Println(scanner.Text())
-		}
+	done := make(chan error, 1)
+	go streamLogs(stdout, opts.since, done)
 
-		if err := scanner.Err(); err != nil {
-			done <- fmt.Errorf("error scanning: %v", err)
-			return
-		}
-
-		if err := stdout.Close(); err != nil {
-			done <- fmt.Errorf("close stdout: %v", err)
-			return
-		}
-		done <- nil
-	}()
+	if opts.postCreate {
+		go func() {
+			_ = codespaces.PollPostCreateStates(ctx, log, apiClient, user, codespace, printPostCreateStates)
+		}()
+	}
 
 	select {
 	case err := <-connClosed:
+		cancel()
 		if err != nil {
 			return fmt.Errorf("connection closed: %v", err)
 		}
+		return nil
 	case err := <-done:
-		if err != nil {
-			return err
+		cancel()
+		return err
+	case <-ctx.Done():
+		return nil
+	}
+}
+
+// logsCommand builds the remote shell command used to read or follow the
+// creation log, depending on the requested mode.
+func logsCommand(opts logsOptions) string {
+	if opts.follow {
+		return fmt.Sprintf("tail -n %d -F %s", opts.tailLines, creationLogPath)
+	}
+	return fmt.Sprintf("cat %s", creationLogPath)
+}
+
+// streamLogs copies lines from stdout to the terminal, optionally filtering
+// out lines whose leading timestamp is older than the since cutoff. It exits
+// (and closes stdout) once the scanner runs dry or errors.
+func streamLogs(stdout io.ReadCloser, since time.Duration, done chan<- error) {
+	var cutoff time.Time
+	if since > 0 {
+		cutoff = time.Now().Add(-since)
+	}
+
+	scanner := bufio.NewScanner(stdout)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !cutoff.IsZero() {
+			if ts, ok := parseLogTimestamp(line); ok && ts.Before(cutoff) {
+				continue
+			}
 		}
+		fmt.Println(line)
 	}
 
-	return nil
+	if err := scanner.Err(); err != nil {
+		done <- fmt.Errorf("error scanning: %v", err)
+		return
+	}
+
+	if err := stdout.Close(); err != nil {
+		done <- fmt.Errorf("close stdout: %v", err)
+		return
+	}
+	done <- nil
+}
+
+var logTimestampRE = regexp.MustCompile(`^\[?(\d{10,})\]?`)
+
+// parseLogTimestamp extracts a leading unix timestamp from a creation.log
+// line, if one is present.
+func parseLogTimestamp(line string) (time.Time, bool) {
+	m := logTimestampRE.FindStringSubmatch(line)
+	if m == nil {
+		return time.Time{}, false
+	}
+	sec, err := strconv.ParseInt(m[1], 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(sec, 0), true
+}
+
+func printPostCreateStates(states []codespaces.PostCreateState) {
+	names := make([]string, len(states))
+	for i, s := range states {
+		names[i] = fmt.Sprintf("%s: %s", s.Name, s.Status)
+	}
+	fmt.Println(strings.Join(names, ", "))
 }
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// chunkedReadCloser feeds back its chunks one at a time, each call to Read
+// returning at most one chunk, to exercise streamLogs against a command that
+// arrives in pieces rather than all at once.
+type chunkedReadCloser struct {
+	chunks [][]byte
+	closed bool
+}
+
+func (c *chunkedReadCloser) Read(p []byte) (int, error) {
+	if len(c.chunks) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(p, c.chunks[0])
+	c.chunks = c.chunks[1:]
+	return n, nil
+}
+
+func (c *chunkedReadCloser) Close() error {
+	c.closed = true
+	return nil
+}
+
+func newFakeRunCommand(chunks ...string) func() io.ReadCloser {
+	raw := make([][]byte, len(chunks))
+	for i, c := range chunks {
+		raw[i] = []byte(c)
+	}
+	return func() io.ReadCloser {
+		return &chunkedReadCloser{chunks: raw}
+	}
+}
+
+func TestStreamLogs_chunked(t *testing.T) {
+	stdout := newFakeRunCommand("line one\n", "line two\n", "line three\n")()
+
+	done := make(chan error, 1)
+	streamLogs(stdout, 0, done)
+
+	if err := <-done; err != nil {
+		t.Fatalf("streamLogs returned error: %v", err)
+	}
+
+	if !stdout.(*chunkedReadCloser).closed {
+		t.Fatal("expected stdout to be closed once the stream drained")
+	}
+}
+
+func TestStreamLogs_sinceFiltersOldLines(t *testing.T) {
+	old := time.Now().Add(-time.Hour).Unix()
+	recent := time.Now().Unix()
+	stdout := newFakeRunCommand(
+		"["+strconv.FormatInt(old, 10)+"] too old\n",
+		"["+strconv.FormatInt(recent, 10)+"] recent enough\n",
+	)()
+
+	done := make(chan error, 1)
+	streamLogs(stdout, 10*time.Minute, done)
+
+	if err := <-done; err != nil {
+		t.Fatalf("streamLogs returned error: %v", err)
+	}
+}
+
+func TestRunRemoteCommandSeam(t *testing.T) {
+	orig := runRemoteCommand
+	t.Cleanup(func() { runRemoteCommand = orig })
+
+	fake := newFakeRunCommand("hello\n", "world\n")
+	runRemoteCommand = func(_ context.Context, _ int, _, _ string) (io.ReadCloser, error) {
+		return fake(), nil
+	}
+
+	stdout, err := runRemoteCommand(context.Background(), 0, "codespace@localhost", "cat /does/not/matter")
+	if err != nil {
+		t.Fatalf("runRemoteCommand returned error: %v", err)
+	}
+
+	done := make(chan error, 1)
+	streamLogs(stdout, 0, done)
+	if err := <-done; err != nil {
+		t.Fatalf("streamLogs returned error: %v", err)
+	}
+}
+
+func TestLogsCommand(t *testing.T) {
+	tail := logsCommand(logsOptions{follow: true, tailLines: 50})
+	if tail != "tail -n 50 -F "+creationLogPath {
+		t.Fatalf("unexpected follow command: %q", tail)
+	}
+
+	cat := logsCommand(logsOptions{})
+	if cat != "cat "+creationLogPath {
+		t.Fatalf("unexpected one-shot command: %q", cat)
+	}
+}
+
+func TestParseLogTimestamp(t *testing.T) {
+	ts, ok := parseLogTimestamp("[1609459200] hello")
+	if !ok {
+		t.Fatal("expected a timestamp to be parsed")
+	}
+	if ts.Unix() != 1609459200 {
+		t.Fatalf("unexpected timestamp: %v", ts)
+	}
+
+	if _, ok := parseLogTimestamp("no timestamp here"); ok {
+		t.Fatal("did not expect a timestamp to be parsed")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/github/ghcs/internal/codespaces"
 	"github.com/spf13/cobra"
 )
 
@@ -18,6 +19,23 @@ var rootCmd = &cobra.Command{
 	Version: "0.7.1",
 }
 
+var (
+	logLevel string
+	logJSON  bool
+)
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "info", "Set the logging level: trace, debug, info, warn, or error")
+	rootCmd.PersistentFlags().BoolVar(&logJSON, "log-json", false, "Emit log output as JSON")
+}
+
+// rootLogger builds the structured logger shared by the codespaces
+// subsystem's connection and polling entry points, configured from the
+// --log-level and --log-json flags.
+func rootLogger() codespaces.Logger {
+	return codespaces.NewLogger(logLevel, logJSON)
+}
+
 func Execute() {
 	if os.Getenv("GITHUB_TOKEN") == "" {
 		fmt.Println("The GITHUB_TOKEN environment variable is required. Create a Personal Access Token at https://github.com/settings/tokens/new?scopes=repo and make sure to enable SSO for the GitHub organization after creating the token.")
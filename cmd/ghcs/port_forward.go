@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/github/ghcs/api"
+	"github.com/github/ghcs/internal/codespaces"
+	"github.com/github/go-liveshare"
+	"github.com/spf13/cobra"
+)
+
+func NewPortForwardCmd() *cobra.Command {
+	var codespaceName string
+	var forwards []string
+
+	portForwardCmd := &cobra.Command{
+		Use:   "port-forward",
+		Short: "Forward ports from a Codespace",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return PortForward(codespaceName, forwards)
+		},
+	}
+
+	portForwardCmd.Flags().StringVarP(&codespaceName, "codespace", "c", "", "Name of the Codespace")
+	portForwardCmd.Flags().StringArrayVarP(&forwards, "forward", "L", nil, "Forward a port from the Codespace, in the form host:remote")
+
+	return portForwardCmd
+}
+
+func init() {
+	rootCmd.AddCommand(NewPortForwardCmd())
+}
+
+type portPair struct {
+	local, remote int
+}
+
+func parsePortPair(raw string) (portPair, error) {
+	parts := strings.SplitN(raw, ":", 2)
+	if len(parts) != 2 {
+		return portPair{}, fmt.Errorf("invalid port forwarding spec %q, expected host:remote", raw)
+	}
+	local, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return portPair{}, fmt.Errorf("invalid local port %q: %v", parts[0], err)
+	}
+	remote, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return portPair{}, fmt.Errorf("invalid remote port %q: %v", parts[1], err)
+	}
+	return portPair{local, remote}, nil
+}
+
+func PortForward(codespaceName string, forwards []string) error {
+	if len(forwards) == 0 {
+		return fmt.Errorf("at least one -L host:remote forward is required")
+	}
+
+	pairs := make([]portPair, 0, len(forwards))
+	for _, raw := range forwards {
+		pair, err := parsePortPair(raw)
+		if err != nil {
+			return err
+		}
+		pairs = append(pairs, pair)
+	}
+
+	apiClient := api.New(os.Getenv("GITHUB_TOKEN"))
+	ctx := context.Background()
+
+	user, err := apiClient.GetUser(ctx)
+	if err != nil {
+		return fmt.Errorf("getting user: %v", err)
+	}
+
+	codespace, token, err := codespaces.GetOrChooseCodespace(ctx, apiClient, user, codespaceName)
+	if err != nil {
+		return fmt.Errorf("get or choose codespace: %v", err)
+	}
+
+	lsclient, err := codespaces.ConnectToLiveshare(ctx, rootLogger(), apiClient, user.Login, token, codespace)
+	if err != nil {
+		return fmt.Errorf("connecting to liveshare: %v", err)
+	}
+
+	connClosed := make(chan error, 1)
+	for _, pair := range pairs {
+		if err := forwardPort(ctx, lsclient, pair, connClosed); err != nil {
+			return fmt.Errorf("forward port %d:%d: %v", pair.local, pair.remote, err)
+		}
+	}
+
+	fmt.Printf("Forwarding ports, press Ctrl-C to stop...\n")
+	return <-connClosed
+}
+
+// forwardPort tunnels the remote port over the Live Share session and listens
+// locally on pair.local, accepting connections until ctx is cancelled.
+func forwardPort(ctx context.Context, lsclient *liveshare.Session, pair portPair, connClosed chan error) error {
+	listener, err := net.Listen("tcp", fmt.Sprintf("localhost:%d", pair.local))
+	if err != nil {
+		return fmt.Errorf("listen on local port: %v", err)
+	}
+
+	tunnelPort, tunnelClosed, err := codespaces.StartPortForwarding(ctx, lsclient, fmt.Sprintf("port-forward-%d", pair.remote), pair.remote)
+	if err != nil {
+		listener.Close()
+		return fmt.Errorf("start port forwarding: %v", err)
+	}
+
+	go func() {
+		<-tunnelClosed
+		listener.Close()
+	}()
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				connClosed <- err
+				return
+			}
+			go proxyConn(ctx, conn, tunnelPort)
+		}
+	}()
+
+	return nil
+}
+
+func proxyConn(ctx context.Context, local net.Conn, tunnelPort int) {
+	defer local.Close()
+
+	remote, err := net.Dial("tcp", fmt.Sprintf("localhost:%d", tunnelPort))
+	if err != nil {
+		return
+	}
+	defer remote.Close()
+
+	done := make(chan struct{}, 2)
+	go func() {
+		_, _ = io.Copy(remote, local)
+		done <- struct{}{}
+	}()
+	go func() {
+		_, _ = io.Copy(local, remote)
+		done <- struct{}{}
+	}()
+	<-done
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+
+	"github.com/github/ghcs/api"
+	"github.com/github/ghcs/internal/codespaces"
+	"github.com/spf13/cobra"
+)
+
+func NewSSHCmd() *cobra.Command {
+	var codespaceName string
+	var execCmd string
+
+	sshCmd := &cobra.Command{
+		Use:   "ssh",
+		Short: "SSH into a Codespace",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return SSH(codespaceName, execCmd)
+		},
+	}
+
+	sshCmd.Flags().StringVarP(&codespaceName, "codespace", "c", "", "Name of the Codespace")
+	sshCmd.Flags().StringVarP(&execCmd, "exec", "e", "", "Command to run in the Codespace instead of starting an interactive shell")
+
+	return sshCmd
+}
+
+func init() {
+	rootCmd.AddCommand(NewSSHCmd())
+}
+
+func SSH(codespaceName, execCmd string) error {
+	apiClient := api.New(os.Getenv("GITHUB_TOKEN"))
+	ctx := context.Background()
+
+	user, err := apiClient.GetUser(ctx)
+	if err != nil {
+		return fmt.Errorf("getting user: %v", err)
+	}
+
+	codespace, token, err := codespaces.GetOrChooseCodespace(ctx, apiClient, user, codespaceName)
+	if err != nil {
+		return fmt.Errorf("get or choose codespace: %v", err)
+	}
+
+	lsclient, err := codespaces.ConnectToLiveshare(ctx, rootLogger(), apiClient, user.Login, token, codespace)
+	if err != nil {
+		return fmt.Errorf("connecting to liveshare: %v", err)
+	}
+
+	tunnelPort, connClosed, err := codespaces.MakeSSHTunnel(ctx, lsclient, 0)
+	if err != nil {
+		return fmt.Errorf("make ssh tunnel: %v", err)
+	}
+
+	dst := fmt.Sprintf("%s@localhost", getSSHUser(codespace))
+
+	if execCmd != "" {
+		stdout, err := codespaces.RunCommand(ctx, tunnelPort, dst, execCmd)
+		if err != nil {
+			return fmt.Errorf("run command: %v", err)
+		}
+
+		done := make(chan error)
+		go func() {
+			scanner := bufio.NewScanner(stdout)
+			for scanner.Scan() {
+				fmt.Println(scanner.Text())
+			}
+			if err := scanner.Err(); err != nil {
+				done <- fmt.Errorf("error scanning: %v", err)
+				return
+			}
+			done <- stdout.Close()
+		}()
+
+		select {
+		case err := <-connClosed:
+			if err != nil {
+				return fmt.Errorf("connection closed: %v", err)
+			}
+			return nil
+		case err := <-done:
+			return err
+		}
+	}
+
+	return runLocalSSH(ctx, tunnelPort, dst, connClosed)
+}
+
+// runLocalSSH execs the user's local ssh client against the forwarded port, interactively
+// connecting them to the Codespace container.
+func runLocalSSH(ctx context.Context, tunnelPort int, dst string, connClosed chan error) error {
+	cmd := exec.CommandContext(ctx, "ssh",
+		"-o", "NoHostAuthenticationForLocalhost=yes",
+		"-p", fmt.Sprintf("%d", tunnelPort),
+		dst,
+	)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt)
+	defer signal.Stop(sig)
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("starting ssh: %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+
+	select {
+	case err := <-connClosed:
+		_ = cmd.Process.Kill()
+		if err != nil {
+			return fmt.Errorf("connection closed: %v", err)
+		}
+		return nil
+	case <-sig:
+		_ = cmd.Process.Kill()
+		return nil
+	case err := <-done:
+		return err
+	}
+}
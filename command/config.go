@@ -2,22 +2,30 @@ package command
 
 import (
 	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
 
 	"github.com/MakeNowJust/heredoc"
+	"github.com/github/gh-cli/internal/config"
 	"github.com/spf13/cobra"
 )
 
+// configFileName is the file within a user's config directory that holds
+// their gh settings; it's what `gh config edit` opens.
+const configFileName = "config.yml"
+
 func init() {
 	configCmd.AddCommand(configGetCmd)
 	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configListCmd)
+	configCmd.AddCommand(configEditCmd)
 
 	configGetCmd.Flags().StringP("host", "h", "", "Get per-host setting")
 	configSetCmd.Flags().StringP("host", "h", "", "Set per-host setting")
-
-	// TODO reveal and add usage once we properly support multiple hosts
-	_ = configGetCmd.Flags().MarkHidden("host")
-	// TODO reveal and add usage once we properly support multiple hosts
-	_ = configSetCmd.Flags().MarkHidden("host")
+	configSetCmd.Flags().Bool("force", false, "Set the key even if it is not a recognized configuration key")
+	configListCmd.Flags().StringP("host", "h", "", "List settings for a specific host")
 }
 
 var configCmd = &cobra.Command{
@@ -28,6 +36,8 @@ var configCmd = &cobra.Command{
 Current respected settings:
 - git_protocol: "https" or "ssh". Default is "https".
 - editor: if unset, defaults to environment variables.
+- prompt: "enabled" or "disabled". Default is "enabled".
+- repo_resolvers: comma-separated order of base-repo resolver stages (env, remote, marker, prompt, recent). Default is "env,remote,marker,prompt,recent".
 `,
 }
 
@@ -53,6 +63,24 @@ var configSetCmd = &cobra.Command{
 	RunE: configSet,
 }
 
+var configListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Print a list of configuration keys and values",
+	Example: heredoc.Doc(`
+	$ gh config list
+	$ gh config list --host github.com
+	`),
+	Args: cobra.NoArgs,
+	RunE: configList,
+}
+
+var configEditCmd = &cobra.Command{
+	Use:   "edit",
+	Short: "Open the configuration file in your editor",
+	Args:  cobra.NoArgs,
+	RunE:  configEdit,
+}
+
 func configGet(cmd *cobra.Command, args []string) error {
 	key := args[0]
 	hostname, err := cmd.Flags().GetString("host")
@@ -67,9 +95,15 @@ func configGet(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	val, err := cfg.Get(hostname, key)
+	val, _, err := config.Effective(cfg, config.DefaultSchema(), hostname, key)
 	if err != nil {
-		return err
+		// key isn't one of the recognized schema entries; fall back to
+		// whatever raw value (if any) is stored under it so `config get`
+		// still works for keys the schema doesn't know about yet.
+		val, err = cfg.Get(hostname, key)
+		if err != nil {
+			return err
+		}
 	}
 
 	if val != "" {
@@ -89,6 +123,25 @@ func configSet(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	force, err := cmd.Flags().GetBool("force")
+	if err != nil {
+		return err
+	}
+
+	schema := config.DefaultSchema()
+	keyDef, known := schema.Lookup(key)
+	if !known && !force {
+		return fmt.Errorf("unknown configuration key %q; pass --force to set it anyway", key)
+	}
+	if known {
+		if err := keyDef.Validate(value); err != nil {
+			return err
+		}
+		if hostname != "" && !keyDef.PerHost {
+			return fmt.Errorf("%q does not support per-host configuration", key)
+		}
+	}
+
 	ctx := contextForCommand(cmd)
 
 	cfg, err := ctx.Config()
@@ -108,3 +161,61 @@ func configSet(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+func configList(cmd *cobra.Command, args []string) error {
+	hostname, err := cmd.Flags().GetString("host")
+	if err != nil {
+		return err
+	}
+
+	ctx := contextForCommand(cmd)
+
+	cfg, err := ctx.Config()
+	if err != nil {
+		return err
+	}
+
+	schema := config.DefaultSchema()
+	names := schema.Names()
+	sort.Strings(names)
+
+	out := colorableOut(cmd)
+	for _, name := range names {
+		val, source, err := config.Effective(cfg, schema, hostname, name)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "%s=%s\t# %s\n", name, val, source)
+	}
+
+	return nil
+}
+
+func configEdit(cmd *cobra.Command, args []string) error {
+	ctx := contextForCommand(cmd)
+
+	cfg, err := ctx.Config()
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(cfg.ConfigDir(), configFileName)
+
+	editorCommand := os.Getenv("GH_EDITOR")
+	if editorCommand == "" {
+		editorCommand = os.Getenv("EDITOR")
+	}
+	if editorCommand == "" {
+		return fmt.Errorf("could not determine text editor; set the EDITOR environment variable")
+	}
+
+	// Shell out rather than exec.Command(editorCommand, path) directly: a
+	// real-world EDITOR/GH_EDITOR value often carries arguments (e.g.
+	// "code --wait"), which exec.Command would otherwise treat as part of
+	// the executable's name and fail to resolve. Mirrors utils.EditFile.
+	editCmd := exec.Command("sh", "-c", fmt.Sprintf("%s %q", editorCommand, path))
+	editCmd.Stdin = os.Stdin
+	editCmd.Stdout = os.Stdout
+	editCmd.Stderr = os.Stderr
+	return editCmd.Run()
+}
@@ -0,0 +1,177 @@
+// Package create holds the interactive editor/template flow shared by issue
+// and pull request creation, so each command only has to say where its
+// templates live and what to do with the resulting title and body.
+package create
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/github/gh-cli/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// Template is a single issue/PR template discovered under a *_TEMPLATE
+// directory, with its YAML front matter parsed out of the body.
+type Template struct {
+	Name  string
+	Front map[string]interface{}
+	Body  string
+}
+
+// DiscoverTemplates reads every *.md file directly under dir, parsing out
+// any YAML front matter delimited by "---" lines. A missing dir is not an
+// error; it just yields no templates.
+func DiscoverTemplates(dir string) ([]Template, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var templates []Template
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".md") {
+			continue
+		}
+
+		data, err := ioutil.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		front, body := splitFrontMatter(string(data))
+		var meta map[string]interface{}
+		if front != "" {
+			if err := yaml.Unmarshal([]byte(front), &meta); err != nil {
+				return nil, fmt.Errorf("parsing front matter in %s: %w", e.Name(), err)
+			}
+		}
+
+		templates = append(templates, Template{
+			Name:  strings.TrimSuffix(e.Name(), ".md"),
+			Front: meta,
+			Body:  body,
+		})
+	}
+
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+	return templates, nil
+}
+
+// splitFrontMatter pulls a leading "---\n...\n---\n" YAML block off of text,
+// returning the YAML (without delimiters) and the remaining body. If text
+// has no front matter, front is "" and body is text unchanged.
+func splitFrontMatter(text string) (front, body string) {
+	if !strings.HasPrefix(text, "---\n") {
+		return "", text
+	}
+	rest := text[len("---\n"):]
+	idx := strings.Index(rest, "\n---\n")
+	if idx == -1 {
+		return "", text
+	}
+	return rest[:idx], rest[idx+len("\n---\n"):]
+}
+
+// Flow drives the interactive "seed a scratch file from a template, open it
+// in the editor, parse title and body back out" sequence shared by issue and
+// PR creation. templatesDir is checked for *.md templates; with none, the
+// editor opens on a blank file; with exactly one, it's used as the seed;
+// with more than one, the user is prompted to choose. The chosen template's
+// front matter seeds a default title (prefilled into the editor, so the
+// user can still change it) and default labels/assignees, mirroring what
+// GitHub's web template chooser does with the same front matter keys.
+func Flow(out io.Writer, in io.Reader, templatesDir string) (title, body string, labels, assignees []string, err error) {
+	templates, err := DiscoverTemplates(templatesDir)
+	if err != nil {
+		return "", "", nil, nil, err
+	}
+
+	var tmpl *Template
+	switch len(templates) {
+	case 0:
+	case 1:
+		tmpl = &templates[0]
+	default:
+		tmpl, err = chooseTemplate(out, in, templates)
+		if err != nil {
+			return "", "", nil, nil, err
+		}
+	}
+
+	var seed string
+	if tmpl != nil {
+		seed = tmpl.Body
+		if defaultTitle, ok := tmpl.Front["title"].(string); ok && defaultTitle != "" {
+			seed = defaultTitle + "\n\n" + tmpl.Body
+		}
+		labels = frontStringSlice(tmpl.Front["labels"])
+		assignees = frontStringSlice(tmpl.Front["assignees"])
+	}
+
+	edited, err := utils.EditFile("*.md", seed)
+	if err != nil {
+		return "", "", nil, nil, err
+	}
+
+	title, body, err = splitTitleBody(edited)
+	return title, body, labels, assignees, err
+}
+
+// frontStringSlice converts a front-matter value expected to be a YAML list
+// of strings (e.g. "labels: [bug, needs-triage]") into a []string, ignoring
+// anything of the wrong shape rather than failing the whole template.
+func frontStringSlice(v interface{}) []string {
+	list, ok := v.([]interface{})
+	if !ok {
+		return nil
+	}
+	var out []string
+	for _, item := range list {
+		if s, ok := item.(string); ok {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// chooseTemplate prompts the user to pick one of templates by number,
+// matching the web UI's "/issues/new/choose" picker.
+func chooseTemplate(out io.Writer, in io.Reader, templates []Template) (*Template, error) {
+	fmt.Fprintln(out, "Choose a template:")
+	for i, t := range templates {
+		fmt.Fprintf(out, "  %d. %s\n", i+1, t.Name)
+	}
+	fmt.Fprint(out, "> ")
+
+	var choice int
+	if _, err := fmt.Fscan(in, &choice); err != nil {
+		return nil, fmt.Errorf("invalid selection: %w", err)
+	}
+	if choice < 1 || choice > len(templates) {
+		return nil, fmt.Errorf("invalid selection: %d", choice)
+	}
+	return &templates[choice-1], nil
+}
+
+// splitTitleBody treats the edited scratch file's first line as the title
+// and the remainder (after a blank line) as the body.
+func splitTitleBody(text string) (title, body string, err error) {
+	parts := strings.SplitN(text, "\n\n", 2)
+	title = strings.TrimSpace(parts[0])
+	if title == "" {
+		return "", "", fmt.Errorf("aborting due to empty title")
+	}
+	if len(parts) > 1 {
+		body = strings.TrimSpace(parts[1])
+	}
+	return title, body, nil
+}
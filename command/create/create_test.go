@@ -0,0 +1,42 @@
+package create
+
+import "testing"
+
+func TestSplitFrontMatter(t *testing.T) {
+	front, body := splitFrontMatter("---\nname: Bug report\n---\n## Description\n")
+	if front != "name: Bug report" {
+		t.Errorf("expected front matter %q, got %q", "name: Bug report", front)
+	}
+	if body != "## Description\n" {
+		t.Errorf("expected body %q, got %q", "## Description\n", body)
+	}
+}
+
+func TestSplitFrontMatter_none(t *testing.T) {
+	front, body := splitFrontMatter("## Description\n")
+	if front != "" {
+		t.Errorf("expected no front matter, got %q", front)
+	}
+	if body != "## Description\n" {
+		t.Errorf("expected body unchanged, got %q", body)
+	}
+}
+
+func TestSplitTitleBody(t *testing.T) {
+	title, body, err := splitTitleBody("my title\n\nmy body\n")
+	if err != nil {
+		t.Fatalf("splitTitleBody() returned error: %v", err)
+	}
+	if title != "my title" {
+		t.Errorf("expected title %q, got %q", "my title", title)
+	}
+	if body != "my body" {
+		t.Errorf("expected body %q, got %q", "my body", body)
+	}
+}
+
+func TestSplitTitleBody_emptyTitle(t *testing.T) {
+	if _, _, err := splitTitleBody("\n\nbody\n"); err == nil {
+		t.Error("expected error for empty title, got nil")
+	}
+}
@@ -4,10 +4,13 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 
 	"github.com/github/gh-cli/api"
+	"github.com/github/gh-cli/command/create"
+	"github.com/github/gh-cli/command/output"
 	"github.com/github/gh-cli/utils"
 	"github.com/spf13/cobra"
 	"golang.org/x/crypto/ssh/terminal"
@@ -15,22 +18,18 @@ import (
 
 func init() {
 	RootCmd.AddCommand(issueCmd)
-	issueCmd.AddCommand(
-		&cobra.Command{
-			Use:   "status",
-			Short: "Show status of relevant issues",
-			RunE:  issueList,
-		},
-		&cobra.Command{
-			Use:   "view <issue-number>",
-			Args:  cobra.MinimumNArgs(1),
-			Short: "View an issue in the browser",
-			RunE:  issueView,
-		},
-	)
-	issueCmd.AddCommand(issueCreateCmd)
+	issueCmd.AddCommand(issueStatusCmd, issueViewCmd, issueCreateCmd)
+
 	issueCreateCmd.Flags().StringArrayP("message", "m", nil, "set title and body")
 	issueCreateCmd.Flags().BoolP("web", "w", false, "open the web browser to create an issue")
+	issueCreateCmd.Flags().StringArray("label", nil, "Add a label by name (can be specified multiple times)")
+	issueCreateCmd.Flags().StringArray("assignee", nil, "Assign a user by login (can be specified multiple times)")
+	issueCreateCmd.Flags().String("milestone", "", "Add to a milestone by title or number")
+	issueCreateCmd.Flags().String("project", "", "Add to a project board by name")
+
+	for _, c := range []*cobra.Command{issueStatusCmd, issueViewCmd, issueCreateCmd} {
+		c.Flags().String("output", "", "Output format: {json|yaml|template=<go-template>}")
+	}
 }
 
 var issueCmd = &cobra.Command{
@@ -38,12 +37,33 @@ var issueCmd = &cobra.Command{
 	Short: "Work with GitHub issues",
 	Long:  `Helps you work with issues.`,
 }
+var issueStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show status of relevant issues",
+	RunE:  issueList,
+}
+var issueViewCmd = &cobra.Command{
+	Use:   "view <issue-number>",
+	Args:  cobra.MinimumNArgs(1),
+	Short: "View an issue in the browser",
+	RunE:  issueView,
+}
 var issueCreateCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create a new issue",
 	RunE:  issueCreate,
 }
 
+// outputFormatter returns the Formatter named by cmd's --output flag, or nil
+// if the flag wasn't set (the caller should fall back to its prose output).
+func outputFormatter(cmd *cobra.Command) (output.Formatter, error) {
+	format, err := cmd.Flags().GetString("output")
+	if err != nil || format == "" {
+		return nil, err
+	}
+	return output.New(format)
+}
+
 func issueList(cmd *cobra.Command, args []string) error {
 	ctx := contextForCommand(cmd)
 	apiClient, err := apiClientForContext(ctx)
@@ -66,6 +86,12 @@ func issueList(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if formatter, err := outputFormatter(cmd); err != nil {
+		return err
+	} else if formatter != nil {
+		return formatter.Format(cmd.OutOrStdout(), issuePayload)
+	}
+
 	printHeader("Issues assigned to you")
 	if issuePayload.Assigned != nil {
 		printIssues(issuePayload.Assigned...)
@@ -102,14 +128,27 @@ func issueView(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	var openURL string
-	if number, err := strconv.Atoi(args[0]); err == nil {
-		// TODO: move URL generation into GitHubRepository
-		openURL = fmt.Sprintf("https://github.com/%s/%s/issues/%d", baseRepo.RepoOwner(), baseRepo.RepoName(), number)
-	} else {
+	number, err := strconv.Atoi(args[0])
+	if err != nil {
 		return fmt.Errorf("invalid issue number: '%s'", args[0])
 	}
 
+	if formatter, err := outputFormatter(cmd); err != nil {
+		return err
+	} else if formatter != nil {
+		apiClient, err := apiClientForContext(ctx)
+		if err != nil {
+			return err
+		}
+		issue, err := api.IssueByNumber(apiClient, baseRepo, number)
+		if err != nil {
+			return err
+		}
+		return formatter.Format(cmd.OutOrStdout(), issue)
+	}
+
+	// TODO: move URL generation into GitHubRepository
+	openURL := fmt.Sprintf("https://github.com/%s/%s/issues/%d", baseRepo.RepoOwner(), baseRepo.RepoName(), number)
 	fmt.Printf("Opening %s in your browser.\n", openURL)
 	return utils.OpenInBrowser(openURL)
 }
@@ -134,6 +173,8 @@ func issueCreate(cmd *cobra.Command, args []string) error {
 
 	var title string
 	var body string
+	var defaultLabels []string
+	var defaultAssignees []string
 
 	message, err := cmd.Flags().GetStringArray("message")
 	if err != nil {
@@ -148,13 +189,13 @@ func issueCreate(cmd *cobra.Command, args []string) error {
 	if len(message) > 0 {
 		title = message[0]
 		body = strings.Join(message[1:], "\n\n")
-	} else {
-		// TODO: open the text editor for issue title & body
-		input := os.Stdin
-		if terminal.IsTerminal(int(input.Fd())) {
-			cmd.Println("Enter the issue title and body; press Enter + Ctrl-D when done:")
+	} else if terminal.IsTerminal(int(os.Stdin.Fd())) {
+		title, body, defaultLabels, defaultAssignees, err = create.Flow(cmd.OutOrStdout(), cmd.InOrStdin(), filepath.Join(".github", "ISSUE_TEMPLATE"))
+		if err != nil {
+			return err
 		}
-		inputBytes, err := ioutil.ReadAll(input)
+	} else {
+		inputBytes, err := ioutil.ReadAll(os.Stdin)
 		if err != nil {
 			return err
 		}
@@ -176,11 +217,61 @@ func issueCreate(cmd *cobra.Command, args []string) error {
 		"body":  body,
 	}
 
+	labels, err := cmd.Flags().GetStringArray("label")
+	if err != nil {
+		return err
+	}
+	if len(labels) == 0 {
+		labels = defaultLabels
+	}
+	if len(labels) > 0 {
+		labelIDs, err := api.LabelsToIDs(apiClient, baseRepo, labels)
+		if err != nil {
+			return err
+		}
+		params["labelIds"] = labelIDs
+	}
+
+	assignees, err := cmd.Flags().GetStringArray("assignee")
+	if err != nil {
+		return err
+	}
+	if len(assignees) == 0 {
+		assignees = defaultAssignees
+	}
+	if len(assignees) > 0 {
+		assigneeIDs, err := api.AssigneesToIDs(apiClient, assignees)
+		if err != nil {
+			return err
+		}
+		params["assigneeIds"] = assigneeIDs
+	}
+	if milestone, err := cmd.Flags().GetString("milestone"); err == nil && milestone != "" {
+		milestoneID, err := api.MilestoneToID(apiClient, baseRepo, milestone)
+		if err != nil {
+			return err
+		}
+		params["milestoneId"] = milestoneID
+	}
+	if project, err := cmd.Flags().GetString("project"); err == nil && project != "" {
+		projectID, err := api.ProjectToID(apiClient, baseRepo, project)
+		if err != nil {
+			return err
+		}
+		params["projectIds"] = []string{projectID}
+	}
+
 	newIssue, err := api.IssueCreate(apiClient, baseRepo, params)
 	if err != nil {
 		return err
 	}
 
+	if formatter, err := outputFormatter(cmd); err != nil {
+		return err
+	} else if formatter != nil {
+		return formatter.Format(cmd.OutOrStdout(), newIssue)
+	}
+
 	fmt.Fprintln(cmd.OutOrStdout(), newIssue.URL)
 	return nil
 }
@@ -0,0 +1,66 @@
+// Package output provides cross-cutting, scriptable renderers for command
+// output, selected via a --output json|yaml|template=<tmpl> flag, as an
+// alternative to the human-oriented prose commands print by default.
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Formatter renders v to w in some stable, scriptable encoding. Commands
+// register the data they want printed and let the user-selected Formatter
+// decide how it's serialized, instead of hand-rolling their own prose.
+type Formatter interface {
+	Format(w io.Writer, v interface{}) error
+}
+
+type jsonFormatter struct{}
+
+func (jsonFormatter) Format(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+type yamlFormatter struct{}
+
+func (yamlFormatter) Format(w io.Writer, v interface{}) error {
+	enc := yaml.NewEncoder(w)
+	defer enc.Close()
+	return enc.Encode(v)
+}
+
+type templateFormatter struct {
+	tmpl *template.Template
+}
+
+func (f templateFormatter) Format(w io.Writer, v interface{}) error {
+	return f.tmpl.Execute(w, v)
+}
+
+// New builds the Formatter named by format, one of "json", "yaml", or
+// "template=<go-template>". It returns an error for any other value so
+// commands can report an unrecognized --output flag instead of guessing.
+func New(format string) (Formatter, error) {
+	switch {
+	case format == "json":
+		return jsonFormatter{}, nil
+	case format == "yaml":
+		return yamlFormatter{}, nil
+	case strings.HasPrefix(format, "template="):
+		tmplText := strings.TrimPrefix(format, "template=")
+		tmpl, err := template.New("output").Parse(tmplText)
+		if err != nil {
+			return nil, fmt.Errorf("invalid template: %w", err)
+		}
+		return templateFormatter{tmpl: tmpl}, nil
+	default:
+		return nil, fmt.Errorf("unsupported output format: %q", format)
+	}
+}
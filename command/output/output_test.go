@@ -0,0 +1,58 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestNew_json(t *testing.T) {
+	f, err := New("json")
+	if err != nil {
+		t.Fatalf("New() returned error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := f.Format(&buf, map[string]string{"title": "hello"}); err != nil {
+		t.Fatalf("Format() returned error: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"title": "hello"`) {
+		t.Errorf("expected JSON output to contain title field, got %q", buf.String())
+	}
+}
+
+func TestNew_yaml(t *testing.T) {
+	f, err := New("yaml")
+	if err != nil {
+		t.Fatalf("New() returned error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := f.Format(&buf, map[string]string{"title": "hello"}); err != nil {
+		t.Fatalf("Format() returned error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "title: hello") {
+		t.Errorf("expected YAML output to contain title field, got %q", buf.String())
+	}
+}
+
+func TestNew_template(t *testing.T) {
+	f, err := New("template={{.Title}}")
+	if err != nil {
+		t.Fatalf("New() returned error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := f.Format(&buf, struct{ Title string }{"hello"}); err != nil {
+		t.Fatalf("Format() returned error: %v", err)
+	}
+	if buf.String() != "hello" {
+		t.Errorf("expected template output %q, got %q", "hello", buf.String())
+	}
+}
+
+func TestNew_unsupportedFormat(t *testing.T) {
+	if _, err := New("xml"); err == nil {
+		t.Error("expected error for unsupported format, got nil")
+	}
+}
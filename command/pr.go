@@ -0,0 +1,335 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/github/gh-cli/api"
+	"github.com/github/gh-cli/command/create"
+	"github.com/github/gh-cli/command/output"
+	"github.com/spf13/cobra"
+)
+
+var prCmd = &cobra.Command{
+	Use:   "pr",
+	Short: "Work with GitHub pull requests",
+	Long:  `Helps you work with pull requests.`,
+}
+
+var prStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show status of relevant pull requests",
+	RunE:  prStatus,
+}
+
+var prChecksCmd = &cobra.Command{
+	Use:   "checks <number>",
+	Short: "Show CI status for a single pull request",
+	Args:  cobra.ExactArgs(1),
+	RunE:  prChecks,
+}
+
+var prCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a pull request",
+	RunE:  prCreate,
+}
+
+func init() {
+	RootCmd.AddCommand(prCmd)
+	prCmd.AddCommand(prStatusCmd)
+	prCmd.AddCommand(prChecksCmd)
+	prCmd.AddCommand(prCreateCmd)
+
+	prChecksCmd.Flags().Bool("watch", false, "Poll until all checks reach a terminal state")
+
+	prCreateCmd.Flags().String("base", "", "The branch into which you want your code merged")
+	prCreateCmd.Flags().String("head", "", "The branch that contains commits for your pull request (default: current branch)")
+	prCreateCmd.Flags().Bool("draft", false, "Mark the pull request as a draft")
+	prCreateCmd.Flags().StringArray("reviewer", nil, "Request a review from a user (can be specified multiple times)")
+	prCreateCmd.Flags().StringArray("label", nil, "Add a label by name (can be specified multiple times)")
+	prCreateCmd.Flags().StringArray("assignee", nil, "Assign a user by login (can be specified multiple times)")
+	prCreateCmd.Flags().String("milestone", "", "Add to a milestone by title or number")
+
+	for _, c := range []*cobra.Command{prStatusCmd, prChecksCmd, prCreateCmd} {
+		c.Flags().String("output", "", "Output format: {json|yaml|template=<go-template>}")
+	}
+}
+
+// prOutputFormatter returns the Formatter named by cmd's --output flag, or
+// nil if the flag wasn't set (the caller should fall back to its prose
+// output).
+func prOutputFormatter(cmd *cobra.Command) (output.Formatter, error) {
+	format, err := cmd.Flags().GetString("output")
+	if err != nil || format == "" {
+		return nil, err
+	}
+	return output.New(format)
+}
+
+func prStatus(cmd *cobra.Command, args []string) error {
+	ctx := contextForCommand(cmd)
+	apiClient, err := apiClientForContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	baseRepo, err := ctx.BaseRepo()
+	if err != nil {
+		return err
+	}
+
+	currentBranch, err := ctx.Branch()
+	if err != nil {
+		return err
+	}
+
+	currentUser, err := ctx.AuthLogin()
+	if err != nil {
+		return err
+	}
+
+	prPayload, err := api.PullRequests(context.Background(), apiClient, baseRepo, currentBranch, currentUser)
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+
+	if formatter, err := prOutputFormatter(cmd); err != nil {
+		return err
+	} else if formatter != nil {
+		return formatter.Format(out, prPayload)
+	}
+
+	printHeader("Current branch")
+	if prPayload.CurrentPR != nil {
+		printPRStatus(out, *prPayload.CurrentPR)
+	} else {
+		printMessage("  There is no pull request associated with this branch")
+	}
+	fmt.Fprintln(out)
+
+	printHeader("Created by you")
+	if len(prPayload.ViewerCreated) > 0 {
+		for _, pr := range prPayload.ViewerCreated {
+			printPRStatus(out, pr)
+		}
+	} else {
+		printMessage("  You have no open pull requests")
+	}
+	fmt.Fprintln(out)
+
+	printHeader("Requesting a code review from you")
+	if len(prPayload.ReviewRequested) > 0 {
+		for _, pr := range prPayload.ReviewRequested {
+			printPRStatus(out, pr)
+		}
+	} else {
+		printMessage("  You have no pull requests to review")
+	}
+	fmt.Fprintln(out)
+
+	return nil
+}
+
+// printPRStatus prints a pull request's title alongside a pending/failing/passing
+// checks summary, so a reviewer can tell which PRs need attention without
+// opening each one individually.
+func printPRStatus(out io.Writer, pr api.PullRequest) {
+	fmt.Fprintf(out, "  #%d %s [%s]\n", pr.Number, truncate(50, pr.Title), pr.HeadLabel())
+	if checks := pr.ChecksStatus(); checks.Total > 0 {
+		fmt.Fprintf(out, "  - Checks: %d passing, %d failing, %d pending\n", checks.Passing, checks.Failing, checks.Pending)
+	}
+}
+
+func prChecks(cmd *cobra.Command, args []string) error {
+	ctx := contextForCommand(cmd)
+	apiClient, err := apiClientForContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	baseRepo, err := ctx.BaseRepo()
+	if err != nil {
+		return err
+	}
+
+	number, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid pull request number: '%s'", args[0])
+	}
+
+	watch, err := cmd.Flags().GetBool("watch")
+	if err != nil {
+		return err
+	}
+
+	formatter, err := prOutputFormatter(cmd)
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+
+	for {
+		pr, err := api.PullRequestByNumber(context.Background(), apiClient, baseRepo, number)
+		if err != nil {
+			return fmt.Errorf("could not find pull request: %w", err)
+		}
+
+		checks := pr.Checks()
+
+		if formatter != nil {
+			if err := formatter.Format(out, checks); err != nil {
+				return err
+			}
+		} else if len(checks) == 0 {
+			fmt.Fprintln(out, "No checks reported on this pull request")
+			return nil
+		} else {
+			printChecks(out, checks)
+		}
+
+		if !watch || allChecksTerminal(checks) {
+			return nil
+		}
+
+		time.Sleep(10 * time.Second)
+	}
+}
+
+func printChecks(out io.Writer, checks []api.Check) {
+	for _, c := range checks {
+		fmt.Fprintf(out, "  %-10s %-30s %s\n", c.State, truncate(30, c.Name), c.TargetURL)
+	}
+}
+
+// allChecksTerminal reports whether every check has reached a state that
+// won't change on its own, which is what --watch polls for.
+func allChecksTerminal(checks []api.Check) bool {
+	for _, c := range checks {
+		switch c.State {
+		case "PENDING", "EXPECTED", "REQUESTED", "QUEUED", "IN_PROGRESS":
+			return false
+		}
+	}
+	return true
+}
+
+func prCreate(cmd *cobra.Command, args []string) error {
+	ctx := contextForCommand(cmd)
+	apiClient, err := apiClientForContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	baseRepo, err := ctx.BaseRepo()
+	if err != nil {
+		return err
+	}
+
+	base, err := cmd.Flags().GetString("base")
+	if err != nil {
+		return err
+	}
+	if base == "" {
+		return fmt.Errorf("could not determine base branch; use --base")
+	}
+
+	head, err := cmd.Flags().GetString("head")
+	if err != nil {
+		return err
+	}
+	if head == "" {
+		head, err = ctx.Branch()
+		if err != nil {
+			return err
+		}
+	}
+
+	title, body, defaultLabels, defaultAssignees, err := create.Flow(cmd.OutOrStdout(), cmd.InOrStdin(), filepath.Join(".github", "PULL_REQUEST_TEMPLATE"))
+	if err != nil {
+		return err
+	}
+
+	draft, err := cmd.Flags().GetBool("draft")
+	if err != nil {
+		return err
+	}
+
+	labels, err := cmd.Flags().GetStringArray("label")
+	if err != nil {
+		return err
+	}
+	if len(labels) == 0 {
+		labels = defaultLabels
+	}
+	assignees, err := cmd.Flags().GetStringArray("assignee")
+	if err != nil {
+		return err
+	}
+	if len(assignees) == 0 {
+		assignees = defaultAssignees
+	}
+	reviewers, err := cmd.Flags().GetStringArray("reviewer")
+	if err != nil {
+		return err
+	}
+	milestone, err := cmd.Flags().GetString("milestone")
+	if err != nil {
+		return err
+	}
+
+	params := map[string]interface{}{
+		"title":       title,
+		"body":        body,
+		"baseRefName": base,
+		"headRefName": head,
+		"draft":       draft,
+	}
+
+	if labelIDs, err := api.LabelsToIDs(apiClient, baseRepo, labels); err != nil {
+		return err
+	} else if len(labelIDs) > 0 {
+		params["labelIds"] = labelIDs
+	}
+
+	if assigneeIDs, err := api.AssigneesToIDs(apiClient, assignees); err != nil {
+		return err
+	} else if len(assigneeIDs) > 0 {
+		params["assigneeIds"] = assigneeIDs
+	}
+
+	if milestone != "" {
+		milestoneID, err := api.MilestoneToID(apiClient, baseRepo, milestone)
+		if err != nil {
+			return err
+		}
+		params["milestoneId"] = milestoneID
+	}
+
+	pr, err := api.CreatePullRequest(context.Background(), apiClient, baseRepo, params)
+	if err != nil {
+		return err
+	}
+
+	if err := api.AddReviewers(apiClient, pr, reviewers); err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+
+	if formatter, err := prOutputFormatter(cmd); err != nil {
+		return err
+	} else if formatter != nil {
+		return formatter.Format(out, pr)
+	}
+
+	fmt.Fprintln(out, pr.URL)
+	return nil
+}
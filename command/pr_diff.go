@@ -1,25 +1,30 @@
 package command
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"regexp"
 	"strconv"
 	"strings"
 
-	"github.com/cli/cli/api"
-	"github.com/cli/cli/utils"
+	"github.com/github/gh-cli/api"
+	"github.com/github/gh-cli/utils"
 	"github.com/spf13/cobra"
 )
 
 var prDiffCmd = &cobra.Command{
-	Use:   "diff {<number> | <url>}",
+	Use:   "diff {<number> | <url>} [-- <path>...]",
 	Short: "View a pull request's changes.",
 	RunE:  prDiff,
 }
 
 func init() {
 	prDiffCmd.Flags().StringP("color", "c", "auto", "Whether or not to output color: {always|never|auto}")
+	prDiffCmd.Flags().Bool("stat", false, "Display a diffstat summary instead of the diff itself")
+	prDiffCmd.Flags().Bool("no-pager", false, "Disable the use of a pager for viewing the diff")
 
 	prCmd.AddCommand(prDiffCmd)
 }
@@ -36,6 +41,14 @@ func prDiff(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("could not determine base repo: %w", err)
 	}
 
+	// args after a "--" are a pathspec narrowing which files to show, not
+	// part of the pr number/url selector.
+	var pathspec []string
+	if dashAt := cmd.ArgsLenAtDash(); dashAt > -1 {
+		pathspec = args[dashAt:]
+		args = args[:dashAt]
+	}
+
 	// begin pr resolution boilerplate
 	var prNum int
 	branchWithOwner := ""
@@ -60,7 +73,7 @@ func prDiff(cmd *cobra.Command, args []string) error {
 
 	var pr *api.PullRequest
 	if prNum > 0 {
-		pr, err = api.PullRequestByNumber(apiClient, baseRepo, prNum)
+		pr, err = api.PullRequestByNumber(context.Background(), apiClient, baseRepo, prNum)
 		if err != nil {
 			return fmt.Errorf("could not find pull request: %w", err)
 		}
@@ -78,6 +91,20 @@ func prDiff(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if len(pathspec) > 0 {
+		diff = filterDiffByPathspec(diff, pathspec)
+	}
+
+	showStat, err := cmd.Flags().GetBool("stat")
+	if err != nil {
+		return err
+	}
+	if showStat {
+		out := cmd.OutOrStdout()
+		fmt.Fprint(out, diffStat(diff))
+		return nil
+	}
+
 	color, err := cmd.Flags().GetString("color")
 	if err != nil {
 		return err
@@ -95,14 +122,24 @@ func prDiff(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	noPager, err := cmd.Flags().GetBool("no-pager")
+	if err != nil {
+		return err
+	}
+
 	switch color {
 	case "always":
-		out = colorableOut(cmd)
 		rendered, err := utils.RenderMarkdown(fmt.Sprintf("```diff\n%s\n```", diff))
-		fmt.Fprintf(out, rendered)
 		if err != nil {
 			return fmt.Errorf("failed to colorize diff: %w", err)
 		}
+		if noPager {
+			_, err = fmt.Fprint(colorableOut(cmd), rendered)
+			return err
+		}
+		if err := utils.RunPager(colorableOut(cmd), rendered); err != nil {
+			return fmt.Errorf("failed to page diff: %w", err)
+		}
 	case "never":
 		out := cmd.OutOrStdout()
 		fmt.Fprintf(out, diff)
@@ -112,3 +149,167 @@ func prDiff(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// diffFileHeader matches the "diff --git a/<path> b/<path>" line that marks
+// the start of each file's section in a unified diff.
+var diffFileHeader = regexp.MustCompile(`(?m)^diff --git a/(.+) b/(.+)$`)
+
+// splitDiffSections breaks a unified diff into one string per file, each
+// starting at its "diff --git" header and running up to the next one.
+func splitDiffSections(diff string) []string {
+	locs := diffFileHeader.FindAllStringIndex(diff, -1)
+	if len(locs) == 0 {
+		return nil
+	}
+
+	sections := make([]string, 0, len(locs))
+	for i, loc := range locs {
+		end := len(diff)
+		if i+1 < len(locs) {
+			end = locs[i+1][0]
+		}
+		sections = append(sections, diff[loc[0]:end])
+	}
+	return sections
+}
+
+// diffSectionPaths returns the pre- and post-change paths a diff section
+// applies to. They differ only for a renamed or copied file.
+func diffSectionPaths(section string) (oldPath, newPath string) {
+	m := diffFileHeader.FindStringSubmatch(section)
+	if m == nil {
+		return "", ""
+	}
+	return m[1], m[2]
+}
+
+// diffSectionPath returns the post-change path a diff section applies to.
+func diffSectionPath(section string) string {
+	_, newPath := diffSectionPaths(section)
+	return newPath
+}
+
+// matchesPathspec reports whether path is selected by any of the given
+// pathspecs, matching it exactly, as a directory prefix, or as a glob.
+func matchesPathspec(path string, pathspec []string) bool {
+	for _, spec := range pathspec {
+		spec = strings.TrimPrefix(spec, "./")
+		spec = strings.TrimSuffix(spec, "/")
+		if path == spec || strings.HasPrefix(path, spec+"/") {
+			return true
+		}
+		if ok, _ := filepath.Match(spec, path); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// filterDiffByPathspec keeps only the sections of diff whose file matches
+// one of the given pathspecs.
+func filterDiffByPathspec(diff string, pathspec []string) string {
+	sections := splitDiffSections(diff)
+	if sections == nil {
+		return diff
+	}
+
+	var kept strings.Builder
+	for _, section := range sections {
+		oldPath, newPath := diffSectionPaths(section)
+		if matchesPathspec(oldPath, pathspec) || matchesPathspec(newPath, pathspec) {
+			kept.WriteString(section)
+		}
+	}
+	return kept.String()
+}
+
+// diffStat renders a unified diff as a per-file +/- summary, the same shape
+// as `git diff --stat`.
+func diffStat(diff string) string {
+	sections := splitDiffSections(diff)
+
+	type fileStat struct {
+		path                 string
+		additions, deletions int
+	}
+
+	var stats []fileStat
+	maxNameLen, maxChanges := 0, 0
+	for _, section := range sections {
+		path := diffSectionPath(section)
+		if path == "" {
+			continue
+		}
+
+		var additions, deletions int
+		for _, line := range strings.Split(section, "\n") {
+			switch {
+			case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+				continue
+			case strings.HasPrefix(line, "+"):
+				additions++
+			case strings.HasPrefix(line, "-"):
+				deletions++
+			}
+		}
+
+		stats = append(stats, fileStat{path, additions, deletions})
+		if len(path) > maxNameLen {
+			maxNameLen = len(path)
+		}
+		if changes := additions + deletions; changes > maxChanges {
+			maxChanges = changes
+		}
+	}
+
+	const maxBarWidth = 40
+	scale := maxChanges
+	if scale > maxBarWidth {
+		scale = maxBarWidth
+	}
+
+	var out strings.Builder
+	var totalAdditions, totalDeletions int
+	for _, s := range stats {
+		totalAdditions += s.additions
+		totalDeletions += s.deletions
+
+		changes := s.additions + s.deletions
+		barLen := changes
+		if maxChanges > 0 {
+			barLen = changes * scale / maxChanges
+		}
+		if barLen == 0 && changes > 0 {
+			barLen = 1
+		}
+		plus := 0
+		if changes > 0 {
+			plus = barLen * s.additions / changes
+		}
+		if plus == 0 && s.additions > 0 {
+			plus = 1
+			if plus > barLen {
+				barLen = plus
+			}
+		}
+		minus := barLen - plus
+
+		fmt.Fprintf(&out, " %-*s | %d %s%s\n", maxNameLen, s.path, changes, strings.Repeat("+", plus), strings.Repeat("-", minus))
+	}
+
+	fmt.Fprintf(&out, " %d file%s changed, %d insertion%s(+), %d deletion%s(-)\n",
+		len(stats), plural(len(stats)),
+		totalAdditions, plural(totalAdditions),
+		totalDeletions, plural(totalDeletions))
+
+	return out.String()
+}
+
+// plural returns "s" unless n is exactly 1, for building "N file(s) changed"
+// style messages.
+func plural(n int) string {
+	if n == 1 {
+		return ""
+	}
+	return "s"
+}
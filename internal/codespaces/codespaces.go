@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/AlecAivazis/survey/v2"
@@ -60,9 +61,110 @@ func ChooseCodespace(ctx context.Context, apiClient *api.API, user *api.User) (*
 	return codespace, nil
 }
 
-type logger interface {
-	Print(v ...interface{}) (int, error)
-	Println(v ...interface{}) (int, error)
+// ErrTargetNotFound is returned when a named target does not exist on a codespace.
+var ErrTargetNotFound = errors.New("no target with that name was found on the Codespace")
+
+// ChooseCodespaceTarget behaves like ChooseCodespace, but also resolves a
+// dotted "<codespace>.<target>" selector to a specific api.Target exposed by
+// the codespace's Live Share session, prompting interactively for whichever
+// part of the selector is missing or ambiguous.
+func ChooseCodespaceTarget(ctx context.Context, apiClient *api.API, user *api.User, selector string) (*api.Codespace, *api.Target, error) {
+	codespaceName, targetName := splitTargetSelector(selector)
+
+	var codespace *api.Codespace
+	var err error
+	if codespaceName == "" {
+		codespace, err = ChooseCodespace(ctx, apiClient, user)
+	} else {
+		codespaces, listErr := apiClient.ListCodespaces(ctx, user)
+		if listErr != nil {
+			return nil, nil, fmt.Errorf("error getting Codespaces: %v", listErr)
+		}
+		for _, c := range codespaces {
+			if c.Name == codespaceName {
+				codespace = c
+				break
+			}
+		}
+		if codespace == nil {
+			err = fmt.Errorf("no Codespace found with name %q", codespaceName)
+		}
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	target, err := resolveTarget(codespace, targetName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return codespace, target, nil
+}
+
+// splitTargetSelector splits a "<codespace>.<target>" selector into its two parts.
+// Either part may be empty if the selector doesn't include it.
+func splitTargetSelector(selector string) (codespaceName, targetName string) {
+	parts := strings.SplitN(selector, ".", 2)
+	codespaceName = parts[0]
+	if len(parts) == 2 {
+		targetName = parts[1]
+	}
+	return codespaceName, targetName
+}
+
+// resolveTarget picks the named target from the codespace's advertised
+// targets, prompting the user to choose when the name is empty and more than
+// one target is available.
+func resolveTarget(codespace *api.Codespace, targetName string) (*api.Target, error) {
+	if len(codespace.Targets) == 0 {
+		return nil, nil
+	}
+
+	if targetName != "" {
+		for i := range codespace.Targets {
+			if codespace.Targets[i].Name == targetName {
+				return &codespace.Targets[i], nil
+			}
+		}
+		return nil, ErrTargetNotFound
+	}
+
+	if len(codespace.Targets) == 1 {
+		return &codespace.Targets[0], nil
+	}
+
+	targetNames := make([]string, len(codespace.Targets))
+	for i, t := range codespace.Targets {
+		targetNames[i] = fmt.Sprintf("%s.%s", codespace.Name, t.Name)
+	}
+
+	targetSurvey := []*survey.Question{
+		{
+			Name: "target",
+			Prompt: &survey.Select{
+				Message: "Choose target:",
+				Options: targetNames,
+				Default: targetNames[0],
+			},
+			Validate: survey.Required,
+		},
+	}
+
+	answers := struct {
+		Target string
+	}{}
+	if err := survey.Ask(targetSurvey, &answers); err != nil {
+		return nil, fmt.Errorf("error getting answers: %v", err)
+	}
+
+	_, chosen := splitTargetSelector(answers.Target)
+	for i := range codespace.Targets {
+		if codespace.Targets[i].Name == chosen {
+			return &codespace.Targets[i], nil
+		}
+	}
+	return nil, ErrTargetNotFound
 }
 
 func connectionReady(codespace *api.Codespace) bool {
@@ -73,11 +175,14 @@ func connectionReady(codespace *api.Codespace) bool {
 		codespace.Environment.State == api.CodespaceEnvironmentStateAvailable
 }
 
-func ConnectToLiveshare(ctx context.Context, log logger, apiClient *api.API, userLogin, token string, codespace *api.Codespace) (*liveshare.Session, error) {
+func ConnectToLiveshare(ctx context.Context, log Logger, apiClient *api.API, userLogin, token string, codespace *api.Codespace) (*liveshare.Session, error) {
+	start := time.Now()
+	log = log.With("codespace", codespace.Name)
+
 	var startedCodespace bool
 	if codespace.Environment.State != api.CodespaceEnvironmentStateAvailable {
 		startedCodespace = true
-		log.Print("Starting your Codespace...")
+		log.Info("starting codespace")
 		if err := apiClient.StartCodespace(ctx, token, codespace); err != nil {
 			return nil, fmt.Errorf("error starting Codespace: %v", err)
 		}
@@ -85,10 +190,7 @@ func ConnectToLiveshare(ctx context.Context, log logger, apiClient *api.API, use
 
 	for retries := 0; !connectionReady(codespace); retries++ {
 		if retries > 1 {
-			if retries%2 == 0 {
-				log.Print(".")
-			}
-
+			log.Debug("waiting for codespace to become available", "attempt", retries)
 			time.Sleep(1 * time.Second)
 		}
 
@@ -104,10 +206,10 @@ func ConnectToLiveshare(ctx context.Context, log logger, apiClient *api.API, use
 	}
 
 	if startedCodespace {
-		fmt.Print("\n")
+		log.Info("codespace started", "elapsed", time.Since(start))
 	}
 
-	log.Println("Connecting to your Codespace...")
+	log.Info("connecting to liveshare")
 
 	lsclient, err := liveshare.NewClient(
 		liveshare.WithConnection(liveshare.Connection{
@@ -121,7 +223,12 @@ func ConnectToLiveshare(ctx context.Context, log logger, apiClient *api.API, use
 		return nil, fmt.Errorf("error creating Live Share client: %v", err)
 	}
 
-	return lsclient.JoinWorkspace(ctx)
+	session, err := lsclient.JoinWorkspace(ctx)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug("joined liveshare workspace", "session_id", codespace.Environment.Connection.SessionID, "elapsed", time.Since(start))
+	return session, nil
 }
 
 func GetOrChooseCodespace(ctx context.Context, apiClient *api.API, user *api.User, codespaceName string) (codespace *api.Codespace, token string, err error) {
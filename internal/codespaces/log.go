@@ -0,0 +1,24 @@
+package codespaces
+
+import (
+	"os"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+// Logger is the structured, leveled logger threaded through the codespaces
+// package's connection and polling entry points, so a slow or flaky
+// connection can be diagnosed from --log-level/--log-json output instead of
+// by re-running with prints added.
+type Logger = hclog.Logger
+
+// NewLogger builds the Logger shared by the ghcs commands, configured from
+// the --log-level and --log-json root flags.
+func NewLogger(level string, jsonFormat bool) Logger {
+	return hclog.New(&hclog.LoggerOptions{
+		Name:       "ghcs",
+		Level:      hclog.LevelFromString(level),
+		JSONFormat: jsonFormat,
+		Output:     os.Stderr,
+	})
+}
@@ -31,10 +31,21 @@ type PostCreateState struct {
 	Status PostCreateStateStatus `json:"status"`
 }
 
+// maxConsecutivePollFailures bounds how many times in a row
+// PollPostCreateStates will tolerate getPostCreateOutput failing before
+// giving up and returning an error, so a persistently broken connection
+// (bad token, missing command, a half-dead tunnel that never trips
+// connClosed) doesn't spin forever logging warnings.
+const maxConsecutivePollFailures = 10
+
 // PollPostCreateStates watches for state changes in a codespace,
 // and calls the supplied poller for each batch of state changes.
-// It runs until the context is cancelled or SSH tunnel is closed.
-func PollPostCreateStates(ctx context.Context, log logger, apiClient *api.API, user *api.User, codespace *api.Codespace, poller func([]PostCreateState)) error {
+// It runs until the context is cancelled, the SSH tunnel is closed, or
+// getPostCreateOutput fails maxConsecutivePollFailures times in a row.
+func PollPostCreateStates(ctx context.Context, log Logger, apiClient *api.API, user *api.User, codespace *api.Codespace, poller func([]PostCreateState)) error {
+	start := time.Now()
+	log = log.With("codespace", codespace.Name)
+
 	token, err := apiClient.GetCodespaceToken(ctx, user.Login, codespace.Name)
 	if err != nil {
 		return fmt.Errorf("getting codespace token: %v", err)
@@ -53,6 +64,7 @@ func PollPostCreateStates(ctx context.Context, log logger, apiClient *api.API, u
 	t := time.NewTicker(1 * time.Second)
 	defer t.Stop()
 
+	var consecutiveFailures int
 	for {
 		select {
 		case <-ctx.Done():
@@ -62,9 +74,17 @@ func PollPostCreateStates(ctx context.Context, log logger, apiClient *api.API, u
 		case <-t.C:
 			states, err := getPostCreateOutput(ctx, tunnelPort, codespace)
 			if err != nil {
-				return fmt.Errorf("get post create output: %v", err)
+				consecutiveFailures++
+				if consecutiveFailures >= maxConsecutivePollFailures {
+					return fmt.Errorf("get post create output failed %d times in a row: %w", consecutiveFailures, err)
+				}
+				log.Warn("get post create output failed, will retry on next tick",
+					"error", err, "consecutive_failures", consecutiveFailures, "elapsed", time.Since(start))
+				continue
 			}
 
+			consecutiveFailures = 0
+			log.Debug("polled post create states", "steps", len(states), "elapsed", time.Since(start))
 			poller(states)
 		}
 	}
@@ -0,0 +1,144 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// KeyType is the value type a schema Key accepts.
+type KeyType string
+
+const (
+	KeyTypeString   KeyType = "string"
+	KeyTypeBool     KeyType = "bool"
+	KeyTypeEnum     KeyType = "enum"
+	KeyTypeDuration KeyType = "duration"
+)
+
+// Key describes one recognized configuration key: its type, default,
+// allowed values (for enums), and whether it may be set per-host.
+type Key struct {
+	Name        string
+	Type        KeyType
+	Default     string
+	Description string
+	AllowedVals []string
+	PerHost     bool
+}
+
+// Validate checks that value is a legal value for this key, returning a
+// descriptive error if not.
+func (k Key) Validate(value string) error {
+	switch k.Type {
+	case KeyTypeBool:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("%q is not a valid value for %s; expected true or false", value, k.Name)
+		}
+	case KeyTypeDuration:
+		if _, err := time.ParseDuration(value); err != nil {
+			return fmt.Errorf("%q is not a valid value for %s; expected a duration like \"30s\"", value, k.Name)
+		}
+	case KeyTypeEnum:
+		for _, allowed := range k.AllowedVals {
+			if strings.EqualFold(allowed, value) {
+				return nil
+			}
+		}
+		return fmt.Errorf("%q is not a valid value for %s; valid values are %s", value, k.Name, strings.Join(k.AllowedVals, ", "))
+	case KeyTypeString:
+		// any string is acceptable
+	}
+	return nil
+}
+
+// Schema is the registry of recognized configuration keys.
+type Schema struct {
+	keys map[string]Key
+}
+
+// DefaultSchema describes every key gh currently understands.
+func DefaultSchema() *Schema {
+	s := &Schema{keys: map[string]Key{}}
+	for _, k := range []Key{
+		{
+			Name:        "git_protocol",
+			Type:        KeyTypeEnum,
+			Default:     "https",
+			Description: "the protocol to use for git clone and push operations",
+			AllowedVals: []string{"https", "ssh"},
+			PerHost:     true,
+		},
+		{
+			Name:        "editor",
+			Type:        KeyTypeString,
+			Default:     "",
+			Description: "the text editor program to use for authoring text",
+			PerHost:     false,
+		},
+		{
+			Name:        "prompt",
+			Type:        KeyTypeEnum,
+			Default:     "enabled",
+			Description: "toggle interactive prompting in the terminal",
+			AllowedVals: []string{"enabled", "disabled"},
+			PerHost:     false,
+		},
+		{
+			Name:        "repo_resolvers",
+			Type:        KeyTypeString,
+			Default:     "env,remote,marker,prompt,recent",
+			Description: "comma-separated order of base-repo resolver stages to try after an explicit --repo/GH_REPO override",
+			PerHost:     false,
+		},
+	} {
+		s.keys[k.Name] = k
+	}
+	return s
+}
+
+// Lookup returns the Key definition for name, and whether it was found.
+func (s *Schema) Lookup(name string) (Key, bool) {
+	k, ok := s.keys[name]
+	return k, ok
+}
+
+// Names returns every recognized key name.
+func (s *Schema) Names() []string {
+	names := make([]string, 0, len(s.keys))
+	for name := range s.keys {
+		names = append(names, name)
+	}
+	return names
+}
+
+// Provenance describes where an effective config value came from.
+type Provenance string
+
+const (
+	ProvenanceDefault Provenance = "default"
+	ProvenanceGlobal  Provenance = "global"
+	ProvenanceHost    Provenance = "host"
+)
+
+// Effective resolves the value of key for the given host, reporting whether
+// it came from the schema default, the global setting, or a host override.
+func Effective(cfg Config, schema *Schema, hostname, key string) (value string, source Provenance, err error) {
+	def, ok := schema.Lookup(key)
+	if !ok {
+		return "", "", fmt.Errorf("unknown configuration key %q", key)
+	}
+
+	if hostname != "" && def.PerHost {
+		if v, err := cfg.Get(hostname, key); err == nil && v != "" {
+			return v, ProvenanceHost, nil
+		}
+	}
+
+	if v, err := cfg.Get("", key); err == nil && v != "" {
+		return v, ProvenanceGlobal, nil
+	}
+
+	return def.Default, ProvenanceDefault, nil
+}
@@ -3,10 +3,13 @@ package run
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
+
+	"golang.org/x/crypto/ssh/terminal"
 )
 
 // Runnable is typically an exec.Cmd or its stub in tests
@@ -50,8 +53,8 @@ func (c cmdWithStderr) Output() ([]byte, error) {
 	if c.Cmd.Stderr != nil {
 		return c.Cmd.Output()
 	}
-	errStream := &bytes.Buffer{}
-	c.Cmd.Stderr = errStream
+	errStream := newCappedBuffer(MaxStderrCapture)
+	c.Cmd.Stderr = teeStderr(errStream)
 	out, err := c.Cmd.Output()
 	if err != nil {
 		err = &CmdError{errStream, c.Cmd.Args, err}
@@ -66,8 +69,8 @@ func (c cmdWithStderr) Run() error {
 	if c.Cmd.Stderr != nil {
 		return c.Cmd.Run()
 	}
-	errStream := &bytes.Buffer{}
-	c.Cmd.Stderr = errStream
+	errStream := newCappedBuffer(MaxStderrCapture)
+	c.Cmd.Stderr = teeStderr(errStream)
 	err := c.Cmd.Run()
 	if err != nil {
 		err = &CmdError{errStream, c.Cmd.Args, err}
@@ -75,9 +78,92 @@ func (c cmdWithStderr) Run() error {
 	return err
 }
 
+// MaxStderrCapture bounds how much of a command's stderr cmdWithStderr
+// retains for its error message; a runaway subprocess that streams
+// megabytes to stderr shouldn't cost us megabytes of memory just to build
+// an error string. It's a variable, not a const, so callers that expect
+// unusually chatty subprocesses can raise it.
+var MaxStderrCapture = 64 * 1024
+
+// stderrTee, when non-nil and os.Stderr is a terminal, additionally
+// receives a live copy of a command's stderr as cmdWithStderr captures it,
+// so a long-running command's errors are visible as they happen rather than
+// only once it fails. Defaults to os.Stderr itself; override with
+// SetStderrTee.
+var stderrTee io.Writer = os.Stderr
+
+// SetStderrTee changes where commands' stderr is streamed to while running
+// (in addition to the capped buffer kept for error messages), and returns a
+// func that restores the previous tee target.
+func SetStderrTee(w io.Writer) func() {
+	orig := stderrTee
+	stderrTee = w
+	return func() {
+		stderrTee = orig
+	}
+}
+
+// teeStderr wraps errStream so that, when stderrTee is set and os.Stderr is
+// a terminal, writes also stream live to stderrTee.
+func teeStderr(errStream io.Writer) io.Writer {
+	if stderrTee == nil || !terminal.IsTerminal(int(os.Stderr.Fd())) {
+		return errStream
+	}
+	return io.MultiWriter(errStream, stderrTee)
+}
+
+// cappedBuffer is a bytes.Buffer that retains only the most recent limit
+// bytes written to it, discarding from the front as needed, and remembers
+// that discarding happened so String() can say so. The tail of a failing
+// command's stderr is usually where the actual error message lives, so
+// keeping it (rather than the first limit bytes) is what makes the capture
+// useful for CmdError. Writes past the limit still succeed (returning their
+// full length and a nil error) so callers streaming into it don't see
+// spurious write failures.
+type cappedBuffer struct {
+	buf       bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+func newCappedBuffer(limit int) *cappedBuffer {
+	return &cappedBuffer{limit: limit}
+}
+
+func (c *cappedBuffer) Write(p []byte) (int, error) {
+	n := len(p)
+	if c.limit <= 0 {
+		if n > 0 {
+			c.truncated = true
+		}
+		return n, nil
+	}
+
+	if n >= c.limit {
+		c.truncated = c.truncated || c.buf.Len() > 0 || n > c.limit
+		c.buf.Reset()
+		c.buf.Write(p[n-c.limit:])
+		return n, nil
+	}
+
+	if overflow := c.buf.Len() + n - c.limit; overflow > 0 {
+		c.truncated = true
+		c.buf.Next(overflow)
+	}
+	c.buf.Write(p)
+	return n, nil
+}
+
+func (c *cappedBuffer) String() string {
+	if !c.truncated {
+		return c.buf.String()
+	}
+	return "... (truncated)\n" + c.buf.String()
+}
+
 // CmdError provides more visibility into why an exec.Cmd had failed
 type CmdError struct {
-	Stderr *bytes.Buffer
+	Stderr *cappedBuffer
 	Args   []string
 	Err    error
 }
@@ -0,0 +1,108 @@
+package run
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCappedBuffer_retainsTail(t *testing.T) {
+	tests := []struct {
+		name      string
+		limit     int
+		writes    []string
+		wantStr   string
+		truncated bool
+	}{
+		{
+			name:    "under limit",
+			limit:   10,
+			writes:  []string{"abc"},
+			wantStr: "abc",
+		},
+		{
+			name:    "exactly at limit",
+			limit:   5,
+			writes:  []string{"abcde"},
+			wantStr: "abcde",
+		},
+		{
+			name:      "one byte over limit across writes",
+			limit:     5,
+			writes:    []string{"abcde", "f"},
+			wantStr:   "... (truncated)\nbcdef",
+			truncated: true,
+		},
+		{
+			name:      "single write larger than limit keeps the tail",
+			limit:     5,
+			writes:    []string{"abcdefghij"},
+			wantStr:   "... (truncated)\nfghij",
+			truncated: true,
+		},
+		{
+			name:      "many small writes overflow the limit",
+			limit:     3,
+			writes:    []string{"a", "b", "c", "d"},
+			wantStr:   "... (truncated)\nbcd",
+			truncated: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := newCappedBuffer(tt.limit)
+			for _, w := range tt.writes {
+				n, err := buf.Write([]byte(w))
+				assert.NoError(t, err)
+				assert.Equal(t, len(w), n)
+			}
+			assert.Equal(t, tt.wantStr, buf.String())
+			assert.Equal(t, tt.truncated, buf.truncated)
+		})
+	}
+}
+
+func TestCmdError_Error(t *testing.T) {
+	tests := []struct {
+		name       string
+		stderr     string
+		args       []string
+		underlying string
+		want       string
+	}{
+		{
+			name:       "stderr without trailing newline gets one added",
+			stderr:     "fatal: not a git repository",
+			args:       []string{"git", "status"},
+			underlying: "exit status 128",
+			want:       "fatal: not a git repository\ngit: exit status 128",
+		},
+		{
+			name:       "stderr already ending in newline is left alone",
+			stderr:     "fatal: not a git repository\n",
+			args:       []string{"git", "status"},
+			underlying: "exit status 128",
+			want:       "fatal: not a git repository\ngit: exit status 128",
+		},
+		{
+			name:       "empty stderr omits the leading line",
+			stderr:     "",
+			args:       []string{"git", "status"},
+			underlying: "exit status 1",
+			want:       "git: exit status 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errStream := newCappedBuffer(len(tt.stderr) + 1)
+			_, err := errStream.Write([]byte(tt.stderr))
+			assert.NoError(t, err)
+
+			cmdErr := CmdError{errStream, tt.args, errors.New(tt.underlying)}
+			assert.Equal(t, tt.want, cmdErr.Error())
+		})
+	}
+}
@@ -3,11 +3,14 @@ package browse
 import (
 	"fmt"
 	"net/http"
+	"regexp"
 	"strconv"
+	"strings"
 
-	"github.com/cli/cli/internal/ghrepo"
-	"github.com/cli/cli/pkg/cmdutil"
-	"github.com/cli/cli/pkg/iostreams"
+	"github.com/github/gh-cli/api"
+	"github.com/github/gh-cli/internal/ghrepo"
+	"github.com/github/gh-cli/pkg/cmdutil"
+	"github.com/github/gh-cli/pkg/iostreams"
 	"github.com/spf13/cobra"
 )
 
@@ -22,25 +25,19 @@ type BrowseOptions struct {
 	Browser    browser
 
 	SelectorArg string
-	FileArg     string // Used for storing the file path
-	NumberArg   int    // Used for storing pull request number
+	FileArg     string // the file path, without any :line suffix
+	LineStart   int    // first line to highlight, if any
+	LineEnd     int    // last line to highlight, if any
+	NumberArg   int    // an issue or pull request number
 
+	BranchFlag   string
+	CommitFlag   string
 	ProjectsFlag bool
 	WikiFlag     bool
 	SettingsFlag bool
 }
 
-type exitCode int
-
-const (
-	exitSuccess      exitCode = 0
-	exitNotInRepo    exitCode = 1
-	exitTooManyFlags exitCode = 2
-	exitError        exitCode = 3
-)
-
 func NewCmdBrowse(f *cmdutil.Factory) *cobra.Command {
-
 	opts := &BrowseOptions{
 		IO:         f.IOStreams,
 		HttpClient: f.HttpClient,
@@ -49,105 +46,136 @@ func NewCmdBrowse(f *cmdutil.Factory) *cobra.Command {
 	}
 
 	cmd := &cobra.Command{
-		Long:  "Work with GitHub in the browser", // displays when you are on the help page of this command
-		Short: "Open GitHub in the browser",      // displays in the gh root help
-		Use:   "browse",                          // necessary!!! This is the cmd that gets passed on the prompt
-		Args:  cobra.RangeArgs(0, 1),             // make sure only one arg at most is passed
-
-		Run: func(cmd *cobra.Command, args []string) {
+		Long:  "Work with GitHub in the browser",
+		Short: "Open GitHub in the browser",
+		Use:   "browse [<number> | <path>[:<line>[-<line>]]]",
+		Args:  cobra.RangeArgs(0, 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) > 0 {
 				opts.SelectorArg = args[0]
 			}
-			openInBrowser(cmd, opts) // run gets rid of the usage / runs function
+			return runBrowse(cmd, opts)
 		},
 	}
 
 	cmd.Flags().BoolVarP(&opts.ProjectsFlag, "projects", "p", false, "Open projects tab in browser")
 	cmd.Flags().BoolVarP(&opts.WikiFlag, "wiki", "w", false, "Opens the wiki in browser")
-	cmd.Flags().BoolVarP(&opts.SettingsFlag, "settings", "s", false, "Opens the settings in browse")
+	cmd.Flags().BoolVarP(&opts.SettingsFlag, "settings", "s", false, "Opens the settings in browser")
+	cmd.Flags().StringVarP(&opts.BranchFlag, "branch", "b", "", "Select another branch by passing in the branch name")
+	cmd.Flags().StringVarP(&opts.CommitFlag, "commit", "c", "", "Select another commit by passing in the commit SHA")
 
 	return cmd
 }
 
-func openInBrowser(cmd *cobra.Command, opts *BrowseOptions) {
-
+func runBrowse(cmd *cobra.Command, opts *BrowseOptions) error {
 	baseRepo, err := opts.BaseRepo()
-
-	if !inRepo(err) { // must be in a repo to execute
-		printExit(exitNotInRepo, cmd, opts, "")
-		return
+	if err != nil {
+		return fmt.Errorf("unable to determine base repository: %w", err)
 	}
 
-	if getFlagAmount(cmd) > 1 { // command can't have more than one flag
-		printExit(exitTooManyFlags, cmd, opts, "")
-		return
+	if opts.SelectorArg != "" && (opts.ProjectsFlag || opts.WikiFlag || opts.SettingsFlag) {
+		return fmt.Errorf("a selector argument is not supported with --projects, --wiki, or --settings")
 	}
 
-	repoUrl := ghrepo.GenerateRepoURL(baseRepo, "")
-	parseArgs(opts)
-
-	if opts.SelectorArg == "" {
-		if opts.ProjectsFlag {
-			repoUrl += "/projects"
-			printExit(exitSuccess, cmd, opts, repoUrl)
-		} else if opts.SettingsFlag {
-			repoUrl += "/settings"
-			printExit(exitSuccess, cmd, opts, repoUrl)
-		} else if opts.WikiFlag {
-			repoUrl += "/wiki"
-			printExit(exitSuccess, cmd, opts, repoUrl)
-		} else if getFlagAmount(cmd) == 0 {
-			printExit(exitSuccess, cmd, opts, repoUrl)
-		}
+	if err := parseSelector(opts); err != nil {
+		return err
+	}
 
-		opts.Browser.Browse(repoUrl)
-		return
+	url, err := buildURL(opts, baseRepo)
+	if err != nil {
+		return err
 	}
 
-	printExit(exitError, cmd, opts, "")
+	cs := opts.IO.ColorScheme()
+	fmt.Fprintf(opts.IO.ErrOut, "%s Now opening %s in your browser...\n", cs.Green("✓"), cs.Bold(url))
+
+	return opts.Browser.Browse(url)
 }
 
-func parseArgs(opts *BrowseOptions) {
-	if opts.SelectorArg != "" {
-		convertedArg, err := strconv.Atoi(opts.SelectorArg)
-		if err != nil { //It's not a number, but a file name
-			opts.FileArg = opts.SelectorArg
-		} else { // It's a number, open issue or pull request
-			opts.NumberArg = convertedArg
-		}
+// buildURL resolves opts into the final GitHub URL to open. It is kept pure
+// (apart from the API probe for NumberArg) so it can be table tested without
+// a real browser.
+func buildURL(opts *BrowseOptions, baseRepo ghrepo.Interface) (string, error) {
+	repoURL := ghrepo.GenerateRepoURL(baseRepo, "")
+
+	switch {
+	case opts.ProjectsFlag:
+		return repoURL + "/projects", nil
+	case opts.SettingsFlag:
+		return repoURL + "/settings", nil
+	case opts.WikiFlag:
+		return repoURL + "/wiki", nil
+	case opts.NumberArg > 0:
+		return numberURL(opts, baseRepo, repoURL)
+	case opts.FileArg != "":
+		return fileURL(opts, repoURL)
+	default:
+		return repoURL, nil
 	}
 }
 
-func printExit(errorCode exitCode, cmd *cobra.Command, opts *BrowseOptions, url string) {
-	w := opts.IO.ErrOut
-	cs := opts.IO.ColorScheme()
+func numberURL(opts *BrowseOptions, baseRepo ghrepo.Interface, repoURL string) (string, error) {
+	httpClient, err := opts.HttpClient()
+	if err != nil {
+		return "", err
+	}
+	requester := api.NewRequester(httpClient)
 
-	switch errorCode {
-	case exitSuccess:
-		fmt.Fprintf(opts.IO.ErrOut, "%s Now opening %s in browser . . .\n",
-			opts.IO.ColorScheme().Green("✓"),
-			opts.IO.ColorScheme().Bold(url))
-		break
-	case exitNotInRepo:
-		fmt.Fprintf(w, "%s Change directory to a repository to open in browser\nUse 'gh browse --help' for more information about browse\n",
-			cs.Red("x"))
-		break
-	case exitTooManyFlags:
-		fmt.Fprintf(w, "%s accepts 1 flag, %d flags were recieved\nUse 'gh browse --help' for more information about browse\n",
-			cs.Red("x"), getFlagAmount(cmd))
-		break
-	case exitError:
-		fmt.Fprintf(w, "%s Incorrect use of arguments and flags\nUse 'gh browse --help' for more information about browse\n",
-			cs.Red("x"))
-		break
+	isPR, err := api.IssueOrPullRequest(requester, baseRepo, opts.NumberArg)
+	if err != nil {
+		return "", fmt.Errorf("could not look up #%d: %w", opts.NumberArg, err)
 	}
 
+	if isPR {
+		return fmt.Sprintf("%s/pull/%d", repoURL, opts.NumberArg), nil
+	}
+	return fmt.Sprintf("%s/issues/%d", repoURL, opts.NumberArg), nil
 }
 
-func getFlagAmount(cmd *cobra.Command) int {
-	return cmd.Flags().NFlag()
+func fileURL(opts *BrowseOptions, repoURL string) (string, error) {
+	ref := "HEAD"
+	switch {
+	case opts.CommitFlag != "":
+		ref = opts.CommitFlag
+	case opts.BranchFlag != "":
+		ref = opts.BranchFlag
+	}
+
+	url := fmt.Sprintf("%s/blob/%s/%s", repoURL, ref, opts.FileArg)
+	if opts.LineStart == 0 {
+		return url, nil
+	}
+	if opts.LineEnd == 0 || opts.LineEnd == opts.LineStart {
+		return fmt.Sprintf("%s#L%d", url, opts.LineStart), nil
+	}
+	return fmt.Sprintf("%s#L%d-L%d", url, opts.LineStart, opts.LineEnd), nil
 }
 
-func inRepo(err error) bool {
-	return err == nil
+var lineRangeRE = regexp.MustCompile(`^(.*):(\d+)(?:-(\d+))?$`)
+
+// parseSelector splits SelectorArg into either a file path (with optional
+// :line or :line-line suffix) or a bare issue/PR number.
+func parseSelector(opts *BrowseOptions) error {
+	if opts.SelectorArg == "" {
+		return nil
+	}
+
+	if number, err := strconv.Atoi(opts.SelectorArg); err == nil {
+		opts.NumberArg = number
+		return nil
+	}
+
+	if m := lineRangeRE.FindStringSubmatch(opts.SelectorArg); m != nil {
+		opts.FileArg = m[1]
+		start, _ := strconv.Atoi(m[2])
+		opts.LineStart = start
+		if m[3] != "" {
+			end, _ := strconv.Atoi(m[3])
+			opts.LineEnd = end
+		}
+		return nil
+	}
+
+	opts.FileArg = strings.TrimPrefix(opts.SelectorArg, "/")
+	return nil
 }
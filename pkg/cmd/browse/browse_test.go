@@ -0,0 +1,162 @@
+package browse
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/github/gh-cli/internal/ghrepo"
+	"github.com/github/gh-cli/pkg/httpmock"
+	"github.com/github/gh-cli/pkg/iostreams"
+	"github.com/stretchr/testify/assert"
+)
+
+type stubBrowser struct {
+	url string
+}
+
+func (b *stubBrowser) Browse(url string) error {
+	b.url = url
+	return nil
+}
+
+func TestBuildURL(t *testing.T) {
+	baseRepo := ghrepo.New("OWNER", "REPO")
+
+	tests := []struct {
+		name    string
+		opts    BrowseOptions
+		wantURL string
+	}{
+		{
+			name:    "no selector",
+			opts:    BrowseOptions{},
+			wantURL: "https://github.com/OWNER/REPO",
+		},
+		{
+			name:    "projects flag",
+			opts:    BrowseOptions{ProjectsFlag: true},
+			wantURL: "https://github.com/OWNER/REPO/projects",
+		},
+		{
+			name:    "wiki flag",
+			opts:    BrowseOptions{WikiFlag: true},
+			wantURL: "https://github.com/OWNER/REPO/wiki",
+		},
+		{
+			name:    "settings flag",
+			opts:    BrowseOptions{SettingsFlag: true},
+			wantURL: "https://github.com/OWNER/REPO/settings",
+		},
+		{
+			name:    "file argument",
+			opts:    BrowseOptions{FileArg: "path/to/file.go"},
+			wantURL: "https://github.com/OWNER/REPO/blob/HEAD/path/to/file.go",
+		},
+		{
+			name:    "file with single line",
+			opts:    BrowseOptions{FileArg: "path/to/file.go", LineStart: 42},
+			wantURL: "https://github.com/OWNER/REPO/blob/HEAD/path/to/file.go#L42",
+		},
+		{
+			name:    "file with line range",
+			opts:    BrowseOptions{FileArg: "path/to/file.go", LineStart: 42, LineEnd: 57},
+			wantURL: "https://github.com/OWNER/REPO/blob/HEAD/path/to/file.go#L42-L57",
+		},
+		{
+			name:    "file with branch flag",
+			opts:    BrowseOptions{FileArg: "file.go", BranchFlag: "trunk"},
+			wantURL: "https://github.com/OWNER/REPO/blob/trunk/file.go",
+		},
+		{
+			name:    "file with commit flag",
+			opts:    BrowseOptions{FileArg: "file.go", CommitFlag: "abcd123"},
+			wantURL: "https://github.com/OWNER/REPO/blob/abcd123/file.go",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			url, err := buildURL(&tt.opts, baseRepo)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantURL, url)
+		})
+	}
+}
+
+func TestBuildURL_number(t *testing.T) {
+	baseRepo := ghrepo.New("OWNER", "REPO")
+
+	tests := []struct {
+		name       string
+		typename   string
+		wantSuffix string
+	}{
+		{name: "issue number", typename: "Issue", wantSuffix: "/issues/123"},
+		{name: "pull request number", typename: "PullRequest", wantSuffix: "/pull/123"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reg := &httpmock.Registry{}
+			defer reg.Verify(t)
+			reg.Register(
+				httpmock.GraphQL(`query`),
+				httpmock.StringResponse(`{"data":{"repository":{"issueOrPullRequest":{"__typename":"`+tt.typename+`"}}}}`),
+			)
+
+			opts := &BrowseOptions{
+				NumberArg: 123,
+				HttpClient: func() (*http.Client, error) {
+					return &http.Client{Transport: reg}, nil
+				},
+			}
+
+			url, err := buildURL(opts, baseRepo)
+			assert.NoError(t, err)
+			assert.Contains(t, url, tt.wantSuffix)
+		})
+	}
+}
+
+func TestParseSelector(t *testing.T) {
+	tests := []struct {
+		selector      string
+		wantFile      string
+		wantLineStart int
+		wantLineEnd   int
+		wantNumber    int
+	}{
+		{selector: "", wantFile: ""},
+		{selector: "123", wantNumber: 123},
+		{selector: "path/to/file.go", wantFile: "path/to/file.go"},
+		{selector: "path/to/file.go:42", wantFile: "path/to/file.go", wantLineStart: 42},
+		{selector: "path/to/file.go:42-57", wantFile: "path/to/file.go", wantLineStart: 42, wantLineEnd: 57},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			opts := &BrowseOptions{SelectorArg: tt.selector}
+			assert.NoError(t, parseSelector(opts))
+			assert.Equal(t, tt.wantFile, opts.FileArg)
+			assert.Equal(t, tt.wantLineStart, opts.LineStart)
+			assert.Equal(t, tt.wantLineEnd, opts.LineEnd)
+			assert.Equal(t, tt.wantNumber, opts.NumberArg)
+		})
+	}
+}
+
+func TestRunBrowse_conflictingFlags(t *testing.T) {
+	io, _, _, _ := iostreams.Test()
+	opts := &BrowseOptions{
+		SelectorArg:  "123",
+		ProjectsFlag: true,
+		IO:           io,
+		BaseRepo: func() (ghrepo.Interface, error) {
+			return ghrepo.New("OWNER", "REPO"), nil
+		},
+		Browser: &stubBrowser{},
+	}
+
+	err := runBrowse(nil, opts)
+	assert.Error(t, err)
+}
@@ -0,0 +1,358 @@
+package factory
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/github/gh-cli/api"
+	"github.com/github/gh-cli/git"
+	"github.com/github/gh-cli/internal/config"
+	"github.com/github/gh-cli/internal/ghrepo"
+	"github.com/github/gh-cli/pkg/cmdutil"
+	"github.com/github/gh-cli/pkg/iostreams"
+	"github.com/github/gh-cli/pkg/prompt"
+)
+
+// New returns a Factory wired up with the default resolvers used by the gh binary.
+func New(appVersion string) *cmdutil.Factory {
+	io := iostreams.System()
+
+	f := &cmdutil.Factory{
+		IOStreams: io,
+		Config:    configFunc(),
+	}
+
+	f.HttpClient = httpClientFunc(f, appVersion)
+	f.Remotes = remoteResolver{
+		readRemotes: git.Remotes,
+		getConfig:   f.Config,
+	}.Resolver()
+	f.BaseRepo = BaseRepoFunc(f)
+
+	return f
+}
+
+func configFunc() func() (config.Config, error) {
+	var cachedConfig config.Config
+	var configError error
+	return func() (config.Config, error) {
+		if cachedConfig != nil || configError != nil {
+			return cachedConfig, configError
+		}
+		cachedConfig, configError = config.ParseDefaultConfig()
+		if errors.Is(configError, os.ErrNotExist) {
+			cachedConfig = config.NewBlankConfig()
+			configError = nil
+		}
+		return cachedConfig, configError
+	}
+}
+
+func httpClientFunc(f *cmdutil.Factory, appVersion string) func() (*http.Client, error) {
+	return func() (*http.Client, error) {
+		cfg, err := f.Config()
+		if err != nil {
+			return nil, err
+		}
+		return config.HTTPClientForConfig(cfg, appVersion)
+	}
+}
+
+// remoteResolver determines which git remote corresponds to the configured
+// GitHub host, consulting the user's config for any host aliases along the way.
+type remoteResolver struct {
+	readRemotes func() (git.RemoteSet, error)
+	getConfig   func() (config.Config, error)
+}
+
+// Resolver returns a memoized func that resolves and caches the set of
+// git remotes relevant to gh, ordered the same way `git remote -v` reports them.
+func (rr remoteResolver) Resolver() func() (git.RemoteSet, error) {
+	var cachedRemotes git.RemoteSet
+	var cachedErr error
+
+	return func() (git.RemoteSet, error) {
+		if cachedRemotes != nil || cachedErr != nil {
+			return cachedRemotes, cachedErr
+		}
+
+		cachedRemotes, cachedErr = rr.readRemotes()
+		return cachedRemotes, cachedErr
+	}
+}
+
+// ErrNotApplicable is returned by a resolveFunc to signal that it has no
+// opinion about the base repo, so the chain should fall through to the next one.
+var ErrNotApplicable = errors.New("resolver is not applicable")
+
+// resolveFunc is one link in a RepoResolverChain: given the current
+// environment it either resolves a repo, declines by returning
+// ErrNotApplicable, or fails outright.
+type resolveFunc func(f *cmdutil.Factory) (ghrepo.Interface, error)
+
+// RepoResolverChain tries each resolveFunc in order, falling through on
+// ErrNotApplicable, and returns the first repo resolved (or the last real error).
+type RepoResolverChain []resolveFunc
+
+func (chain RepoResolverChain) Resolve(f *cmdutil.Factory) (ghrepo.Interface, error) {
+	for _, resolve := range chain {
+		repo, err := resolve(f)
+		if err == nil {
+			return repo, nil
+		}
+		if !errors.Is(err, ErrNotApplicable) {
+			return nil, err
+		}
+	}
+	return nil, errors.New("unable to determine base repository, use `--repo OWNER/REPO` to override")
+}
+
+// repoResolverStages are the named resolver stages that can be reordered
+// via the repo_resolvers config key. "remote" isn't listed here since
+// BaseRepoFunc and SmartBaseRepoFunc each supply their own variant of it.
+var repoResolverStages = map[string]resolveFunc{
+	"env":    envOverrideResolver,
+	"marker": markerFileResolver,
+	"prompt": promptResolver,
+	"recent": recentlyUsedResolver,
+}
+
+// defaultRepoResolverOrder is used when repo_resolvers is unset.
+var defaultRepoResolverOrder = []string{"env", "remote", "marker", "prompt", "recent"}
+
+// resolverOrder reads the repo_resolvers config key (a comma-separated list
+// of stage names) and falls back to defaultRepoResolverOrder when it's
+// unset. Unrecognized names are skipped by buildChain rather than erroring,
+// so an extension can add its own stage to repoResolverStages without gh
+// itself knowing about it yet.
+func resolverOrder(f *cmdutil.Factory) []string {
+	cfg, err := f.Config()
+	if err != nil {
+		return defaultRepoResolverOrder
+	}
+
+	raw, err := cfg.Get("", "repo_resolvers")
+	if err != nil || raw == "" {
+		return defaultRepoResolverOrder
+	}
+
+	names := strings.Split(raw, ",")
+	for i, name := range names {
+		names[i] = strings.TrimSpace(name)
+	}
+	return names
+}
+
+// buildChain assembles a RepoResolverChain from an optional explicit
+// --repo argument (always tried first), followed by the stages named in
+// repo_resolvers in the order configured, substituting remoteResolve for
+// the "remote" stage.
+func buildChain(f *cmdutil.Factory, argOverride string, remoteResolve resolveFunc) RepoResolverChain {
+	chain := RepoResolverChain{}
+	if argOverride != "" {
+		chain = append(chain, argOverrideResolver(argOverride))
+	}
+
+	for _, name := range resolverOrder(f) {
+		if name == "remote" {
+			chain = append(chain, remoteResolve)
+			continue
+		}
+		if resolve, ok := repoResolverStages[name]; ok {
+			chain = append(chain, resolve)
+		}
+	}
+	return chain
+}
+
+// FullBaseRepoFunc returns a base-repo resolver built from gh's complete
+// resolver chain: an explicit --repo argument always wins, then the
+// remaining stages (GH_REPO, git remotes matching the configured host, a
+// `.gh/repo` marker file, an interactive prompt, and finally a pick from
+// the viewer's recently used repos) run in the order configured by the
+// repo_resolvers config key.
+func FullBaseRepoFunc(f *cmdutil.Factory, argOverride string) func() (ghrepo.Interface, error) {
+	chain := buildChain(f, argOverride, remoteMatchResolver)
+	return func() (ghrepo.Interface, error) {
+		return chain.Resolve(f)
+	}
+}
+
+// BaseRepoFunc returns a base-repo resolver using gh's default resolver
+// chain, with no explicit --repo argument to prioritize.
+func BaseRepoFunc(f *cmdutil.Factory) func() (ghrepo.Interface, error) {
+	return FullBaseRepoFunc(f, "")
+}
+
+// SmartBaseRepoFunc behaves like BaseRepoFunc, but additionally honors a
+// remote's "gh-resolved" git config value: "base" defers to the matched
+// remote as-is, while an "OWNER/REPO" value overrides which repo is used.
+func SmartBaseRepoFunc(f *cmdutil.Factory) func() (ghrepo.Interface, error) {
+	chain := buildChain(f, "", smartRemoteMatchResolver)
+	return func() (ghrepo.Interface, error) {
+		return chain.Resolve(f)
+	}
+}
+
+func configuredHost() string {
+	if host := os.Getenv("GH_HOST"); host != "" {
+		return host
+	}
+	return ghrepo.DefaultHost
+}
+
+func remoteMatchResolver(f *cmdutil.Factory) (ghrepo.Interface, error) {
+	remotes, err := f.Remotes()
+	if err != nil {
+		return nil, err
+	}
+
+	host := configuredHost()
+	for _, r := range remotes {
+		if strings.EqualFold(r.RepoHost(), host) {
+			return r, nil
+		}
+	}
+
+	return nil, ErrNotApplicable
+}
+
+func smartRemoteMatchResolver(f *cmdutil.Factory) (ghrepo.Interface, error) {
+	remotes, err := f.Remotes()
+	if err != nil {
+		return nil, err
+	}
+
+	host := configuredHost()
+	for _, r := range remotes {
+		if !strings.EqualFold(r.RepoHost(), host) {
+			continue
+		}
+		switch r.Resolved {
+		case "", "base":
+			return r, nil
+		default:
+			return ghrepo.FromFullName(r.Resolved)
+		}
+	}
+
+	return nil, ErrNotApplicable
+}
+
+// markerFileResolver reads a `.gh/repo` file checked into the working tree,
+// allowing a project to pin its base repo independent of git remotes.
+func markerFileResolver(f *cmdutil.Factory) (ghrepo.Interface, error) {
+	content, err := os.ReadFile(filepath.Join(".gh", "repo"))
+	if err != nil {
+		return nil, ErrNotApplicable
+	}
+
+	nwo := strings.TrimSpace(string(content))
+	if nwo == "" {
+		return nil, ErrNotApplicable
+	}
+
+	return ghrepo.FromFullName(nwo)
+}
+
+// argOverrideResolver wraps an explicit --repo flag value as a resolveFunc
+// so it can sit at the front of the chain alongside everything else,
+// instead of being special-cased outside it.
+func argOverrideResolver(argOverride string) resolveFunc {
+	return func(f *cmdutil.Factory) (ghrepo.Interface, error) {
+		return ghrepo.FromFullName(argOverride)
+	}
+}
+
+// envOverrideResolver honors the GH_REPO environment variable.
+func envOverrideResolver(f *cmdutil.Factory) (ghrepo.Interface, error) {
+	envOverride := os.Getenv("GH_REPO")
+	if envOverride == "" {
+		return nil, ErrNotApplicable
+	}
+	return ghrepo.FromFullName(envOverride)
+}
+
+// promptResolver asks the user to type an OWNER/REPO when nothing earlier
+// in the chain resolved one. It declines outright in a non-interactive
+// session so scripts get a clear "unable to determine" error instead of
+// hanging on stdin.
+func promptResolver(f *cmdutil.Factory) (ghrepo.Interface, error) {
+	if f.IOStreams == nil || !f.IOStreams.CanPrompt() {
+		return nil, ErrNotApplicable
+	}
+
+	nwo := ""
+	if err := prompt.SurveyAskOne(&survey.Input{
+		Message: "Which repository should gh use (OWNER/REPO)?",
+	}, &nwo); err != nil {
+		return nil, fmt.Errorf("could not prompt: %w", err)
+	}
+	if nwo == "" {
+		return nil, ErrNotApplicable
+	}
+
+	return ghrepo.FromFullName(nwo)
+}
+
+// recentlyUsedResolver is the last resort before the chain gives up: it asks
+// the API for the viewer's most recently pushed-to repositories and, in an
+// interactive session, lets the user pick one.
+func recentlyUsedResolver(f *cmdutil.Factory) (ghrepo.Interface, error) {
+	if f.IOStreams == nil || !f.IOStreams.CanPrompt() {
+		return nil, ErrNotApplicable
+	}
+
+	httpClient, err := f.HttpClient()
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Viewer struct {
+			Repositories struct {
+				Nodes []struct {
+					NameWithOwner string
+				}
+			}
+		}
+	}
+
+	query := `query RecentRepos {
+		viewer {
+			repositories(first: 10, orderBy: {field: PUSHED_AT, direction: DESC}, ownerAffiliations: OWNER) {
+				nodes {
+					nameWithOwner
+				}
+			}
+		}
+	}`
+
+	requester := api.NewRequester(httpClient)
+	if err := requester.GraphQL(configuredHost(), query, nil, &resp); err != nil {
+		return nil, err
+	}
+	if len(resp.Viewer.Repositories.Nodes) == 0 {
+		return nil, ErrNotApplicable
+	}
+
+	choices := make([]string, 0, len(resp.Viewer.Repositories.Nodes))
+	for _, n := range resp.Viewer.Repositories.Nodes {
+		choices = append(choices, n.NameWithOwner)
+	}
+
+	choice := ""
+	if err := prompt.SurveyAskOne(&survey.Select{
+		Message: "Which repository should gh use?",
+		Options: choices,
+	}, &choice); err != nil {
+		return nil, fmt.Errorf("could not prompt: %w", err)
+	}
+
+	return ghrepo.FromFullName(choice)
+}
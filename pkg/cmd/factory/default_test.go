@@ -3,12 +3,13 @@ package factory
 import (
 	"net/url"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/MakeNowJust/heredoc"
-	"github.com/cli/cli/git"
-	"github.com/cli/cli/internal/config"
-	"github.com/cli/cli/pkg/cmdutil"
+	"github.com/github/gh-cli/git"
+	"github.com/github/gh-cli/internal/config"
+	"github.com/github/gh-cli/pkg/cmdutil"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -272,6 +273,116 @@ func Test_OverrideBaseRepo(t *testing.T) {
 	}
 }
 
+// Test_BaseRepoResolverChain_MarkerFileCorrects exercises the case a linear
+// override couldn't express: git remotes don't match the configured host at
+// all, so the chain falls through to the `.gh/repo` marker file instead of
+// failing outright.
+func Test_BaseRepoResolverChain_MarkerFileCorrects(t *testing.T) {
+	orig_GH_HOST := os.Getenv("GH_HOST")
+	t.Cleanup(func() {
+		os.Setenv("GH_HOST", orig_GH_HOST)
+	})
+	os.Unsetenv("GH_HOST")
+
+	dir := t.TempDir()
+	wd, err := os.Getwd()
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+	assert.NoError(t, os.Chdir(dir))
+
+	assert.NoError(t, os.Mkdir(".gh", 0700))
+	assert.NoError(t, os.WriteFile(filepath.Join(".gh", "repo"), []byte("marker/owner-repo\n"), 0600))
+
+	f := New("1")
+	rr := &remoteResolver{
+		readRemotes: func() (git.RemoteSet, error) {
+			return git.RemoteSet{
+				git.NewRemote("origin", "https://unrelated-host.example/owner/repo.git"),
+			}, nil
+		},
+		getConfig: func() (config.Config, error) {
+			return defaultConfig(), nil
+		},
+	}
+	f.Remotes = rr.Resolver()
+	f.BaseRepo = BaseRepoFunc(f)
+
+	repo, err := f.BaseRepo()
+	assert.NoError(t, err)
+	assert.Equal(t, "marker", repo.RepoOwner())
+	assert.Equal(t, "owner-repo", repo.RepoName())
+}
+
+// Test_BaseRepoResolverChain_ConfiguredOrder exercises the repo_resolvers
+// config key: with "marker" ordered ahead of "remote", a `.gh/repo` marker
+// file wins even though a remote matching the configured host also exists.
+func Test_BaseRepoResolverChain_ConfiguredOrder(t *testing.T) {
+	orig_GH_HOST := os.Getenv("GH_HOST")
+	t.Cleanup(func() {
+		os.Setenv("GH_HOST", orig_GH_HOST)
+	})
+	os.Unsetenv("GH_HOST")
+
+	dir := t.TempDir()
+	wd, err := os.Getwd()
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+	assert.NoError(t, os.Chdir(dir))
+
+	assert.NoError(t, os.Mkdir(".gh", 0700))
+	assert.NoError(t, os.WriteFile(filepath.Join(".gh", "repo"), []byte("marker/owner-repo\n"), 0600))
+
+	cfg := config.InheritEnv(config.NewFromString(heredoc.Doc(`
+    hosts:
+      nonsense.com:
+        oauth_token: BLAH
+    repo_resolvers: marker,remote
+		`)))
+
+	f := New("1")
+	rr := &remoteResolver{
+		readRemotes: func() (git.RemoteSet, error) {
+			return git.RemoteSet{
+				git.NewRemote("origin", "https://nonsense.com/owner/repo.git"),
+			}, nil
+		},
+		getConfig: func() (config.Config, error) {
+			return cfg, nil
+		},
+	}
+	f.Remotes = rr.Resolver()
+	f.Config = func() (config.Config, error) { return cfg, nil }
+	f.BaseRepo = BaseRepoFunc(f)
+
+	repo, err := f.BaseRepo()
+	assert.NoError(t, err)
+	assert.Equal(t, "marker", repo.RepoOwner())
+	assert.Equal(t, "owner-repo", repo.RepoName())
+}
+
+// Test_FullBaseRepoFunc_ArgOverride exercises the arg-override stage now
+// living inside the chain itself, rather than bolted on outside it.
+func Test_FullBaseRepoFunc_ArgOverride(t *testing.T) {
+	f := New("1")
+	rr := &remoteResolver{
+		readRemotes: func() (git.RemoteSet, error) {
+			return git.RemoteSet{
+				git.NewRemote("origin", "https://nonsense.com/owner/repo.git"),
+			}, nil
+		},
+		getConfig: func() (config.Config, error) {
+			return defaultConfig(), nil
+		},
+	}
+	f.Remotes = rr.Resolver()
+	f.BaseRepo = FullBaseRepoFunc(f, "override/test")
+
+	repo, err := f.BaseRepo()
+	assert.NoError(t, err)
+	assert.Equal(t, "override", repo.RepoOwner())
+	assert.Equal(t, "test", repo.RepoName())
+}
+
 func defaultConfig() config.Config {
 	return config.InheritEnv(config.NewFromString(heredoc.Doc(`
     hosts:
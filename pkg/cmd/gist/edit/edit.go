@@ -1,8 +1,7 @@
 package edit
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
 	"errors"
 	"fmt"
 	"io/ioutil"
@@ -13,14 +12,15 @@ import (
 	"strings"
 
 	"github.com/AlecAivazis/survey/v2"
-	"github.com/cli/cli/api"
-	"github.com/cli/cli/internal/config"
-	"github.com/cli/cli/internal/ghinstance"
-	"github.com/cli/cli/pkg/cmd/gist/shared"
-	"github.com/cli/cli/pkg/cmdutil"
-	"github.com/cli/cli/pkg/iostreams"
-	"github.com/cli/cli/pkg/prompt"
-	"github.com/cli/cli/pkg/surveyext"
+	"github.com/github/gh-cli/api"
+	"github.com/github/gh-cli/internal/config"
+	"github.com/github/gh-cli/internal/ghinstance"
+	"github.com/github/gh-cli/pkg/cmd/gist/shared"
+	"github.com/github/gh-cli/pkg/cmdutil"
+	"github.com/github/gh-cli/pkg/iostreams"
+	"github.com/github/gh-cli/pkg/policy"
+	"github.com/github/gh-cli/pkg/prompt"
+	"github.com/github/gh-cli/pkg/surveyext"
 	"github.com/spf13/cobra"
 )
 
@@ -34,6 +34,7 @@ type EditOptions struct {
 	Selector     string
 	EditFilename string
 	AddFilename  string
+	PolicyFile   string
 }
 
 func NewCmdEdit(f *cmdutil.Factory, runF func(*EditOptions) error) *cobra.Command {
@@ -73,10 +74,56 @@ func NewCmdEdit(f *cmdutil.Factory, runF func(*EditOptions) error) *cobra.Comman
 	}
 	cmd.Flags().StringVarP(&opts.AddFilename, "add", "a", "", "Add a file")
 	cmd.Flags().StringVarP(&opts.EditFilename, "filename", "f", "", "Select a file to edit")
+	cmd.Flags().StringVar(&opts.PolicyFile, "policy", "", "Run policy checks from the given policy.yaml before submitting")
 
 	return cmd
 }
 
+// runPolicyChecks runs the registered policy checks (as selected by
+// opts.PolicyFile) against the gist's working file set and returns an error
+// if any check reports a finding.
+func runPolicyChecks(opts *EditOptions, gist *shared.Gist) error {
+	if opts.PolicyFile == "" {
+		return nil
+	}
+
+	checks, err := policy.LoadChecks(opts.PolicyFile)
+	if err != nil {
+		return err
+	}
+	thresholds, err := policy.LoadThresholds(opts.PolicyFile)
+	if err != nil {
+		return err
+	}
+
+	target := &policy.Target{Files: map[string]string{}}
+	for name, file := range gist.Files {
+		target.Files[name] = file.Content
+	}
+
+	registry := policy.NewRegistry()
+	results, err := registry.Run(context.Background(), target, checks)
+	if err != nil {
+		return err
+	}
+
+	cs := opts.IO.ColorScheme()
+	for name, result := range results {
+		for _, finding := range result.Findings {
+			fmt.Fprintf(opts.IO.ErrOut, "%s %s: %s\n", cs.Red("x"), name, finding.Message)
+		}
+		threshold := thresholds[name]
+		if threshold == 0 {
+			threshold = policy.DefaultThreshold
+		}
+		if result.Score < threshold {
+			return cmdutil.SilentError
+		}
+	}
+
+	return nil
+}
+
 func editRun(opts *EditOptions) error {
 	gistID := opts.Selector
 
@@ -94,13 +141,14 @@ func editRun(opts *EditOptions) error {
 	}
 
 	apiClient := api.NewClientFromHTTP(client)
+	requester := api.NewRequester(client)
 
 	gist, err := shared.GetGist(client, ghinstance.OverridableDefault(), gistID)
 	if err != nil {
 		return err
 	}
 
-	username, err := api.CurrentLoginName(apiClient, ghinstance.OverridableDefault())
+	username, err := api.CurrentLoginName(requester, ghinstance.OverridableDefault())
 	if err != nil {
 		return err
 	}
@@ -124,6 +172,9 @@ func editRun(opts *EditOptions) error {
 		fmt.Printf("%v", files)
 
 		gist.Files = files
+		if err := runPolicyChecks(opts, gist); err != nil {
+			return err
+		}
 		err = updateGist(apiClient, ghinstance.OverridableDefault(), gist)
 		if err != nil {
 			return err
@@ -226,6 +277,10 @@ func editRun(opts *EditOptions) error {
 		return nil
 	}
 
+	if err := runPolicyChecks(opts, gist); err != nil {
+		return err
+	}
+
 	err = updateGist(apiClient, ghinstance.OverridableDefault(), gist)
 	if err != nil {
 		return err
@@ -240,24 +295,13 @@ func updateGist(apiClient *api.Client, hostname string, gist *shared.Gist) error
 		Files:       gist.Files,
 	}
 
-	path := "gists/" + gist.ID
-
-	requestByte, err := json.Marshal(body)
-	if err != nil {
-		return err
-	}
-
-	requestBody := bytes.NewReader(requestByte)
-
-	result := shared.Gist{}
-
-	err = apiClient.REST(hostname, "POST", path, requestBody, &result)
-
-	if err != nil {
-		return err
-	}
-
-	return nil
+	var result shared.Gist
+	return apiClient.NewRequest(hostname).
+		Method("POST").
+		Path("gists/%s", gist.ID).
+		Body(body).
+		Into(&result).
+		Do(context.Background())
 }
 
 func getFilesToAdd(file string, opts *EditOptions) (map[string]*shared.GistFile, error) {
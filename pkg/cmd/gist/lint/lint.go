@@ -0,0 +1,181 @@
+package lint
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/github/gh-cli/internal/ghinstance"
+	"github.com/github/gh-cli/pkg/cmd/gist/shared"
+	"github.com/github/gh-cli/pkg/cmdutil"
+	"github.com/github/gh-cli/pkg/iostreams"
+	"github.com/github/gh-cli/pkg/policy"
+	"github.com/spf13/cobra"
+)
+
+type LintOptions struct {
+	IO         *iostreams.IOStreams
+	HttpClient func() (*http.Client, error)
+
+	Selector   string
+	PolicyFile string
+	Checks     []string
+	AsJSON     bool
+}
+
+func NewCmdLint(f *cmdutil.Factory, runF func(*LintOptions) error) *cobra.Command {
+	opts := LintOptions{
+		IO:         f.IOStreams,
+		HttpClient: f.HttpClient,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "lint {<id> | <url>}",
+		Short: "Run policy checks against a gist",
+		Args:  cmdutil.MinimumArgs(1, "cannot lint: gist argument required"),
+		RunE: func(c *cobra.Command, args []string) error {
+			opts.Selector = args[0]
+
+			if runF != nil {
+				return runF(&opts)
+			}
+
+			return lintRun(&opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.PolicyFile, "policy", "", "Path to a policy.yaml selecting which checks to run and their thresholds")
+	cmd.Flags().StringArrayVar(&opts.Checks, "check", nil, "Run only the named check(s) instead of the full registry")
+	cmd.Flags().BoolVar(&opts.AsJSON, "json", false, "Output findings as JSON for use in CI")
+
+	return cmd
+}
+
+func lintRun(opts *LintOptions) error {
+	gistID := opts.Selector
+	if id, err := shared.GistIDFromURL(gistID); err == nil {
+		gistID = id
+	}
+
+	client, err := opts.HttpClient()
+	if err != nil {
+		return err
+	}
+
+	gist, err := shared.GetGist(client, ghinstance.OverridableDefault(), gistID)
+	if err != nil {
+		return err
+	}
+
+	checks, thresholds, err := loadChecks(opts.PolicyFile, opts.Checks)
+	if err != nil {
+		return err
+	}
+
+	target := &policy.Target{Files: map[string]string{}}
+	for name, file := range gist.Files {
+		target.Files[name] = file.Content
+	}
+
+	registry := policy.NewRegistry()
+	results, err := registry.Run(context.Background(), target, checks)
+	if err != nil {
+		return err
+	}
+
+	if opts.AsJSON {
+		enc := json.NewEncoder(opts.IO.Out)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(results); err != nil {
+			return err
+		}
+		if anyBelowThreshold(results, thresholds) {
+			return cmdutil.SilentError
+		}
+		return nil
+	}
+
+	return printResults(opts.IO, results, thresholds)
+}
+
+// anyBelowThreshold reports whether any check's score fell short of its
+// threshold, the same pass/fail test printResults applies to the prose
+// output, so --json exits non-zero under the same conditions for CI to gate
+// on instead of always succeeding once encoding succeeds.
+func anyBelowThreshold(results map[string]policy.Result, thresholds map[string]int) bool {
+	for name, result := range results {
+		threshold := thresholds[name]
+		if threshold == 0 {
+			threshold = policy.DefaultThreshold
+		}
+		if result.Score < threshold {
+			return true
+		}
+	}
+	return false
+}
+
+func printResults(io *iostreams.IOStreams, results map[string]policy.Result, thresholds map[string]int) error {
+	cs := io.ColorScheme()
+	failed := false
+
+	for _, name := range sortedKeys(results) {
+		result := results[name]
+		threshold := thresholds[name]
+		if threshold == 0 {
+			threshold = policy.DefaultThreshold
+		}
+		if result.Score >= threshold {
+			fmt.Fprintf(io.Out, "%s %s (score: %d)\n", cs.SuccessIconWithColor(cs.Green), name, result.Score)
+			continue
+		}
+		failed = true
+		fmt.Fprintf(io.Out, "%s %s (score: %d, threshold: %d)\n", cs.Red("x"), name, result.Score, threshold)
+		for _, finding := range result.Findings {
+			if finding.File != "" {
+				fmt.Fprintf(io.Out, "  %s: %s\n", finding.File, finding.Message)
+			} else {
+				fmt.Fprintf(io.Out, "  %s\n", finding.Message)
+			}
+		}
+	}
+
+	if failed {
+		return cmdutil.SilentError
+	}
+	return nil
+}
+
+func sortedKeys(results map[string]policy.Result) []string {
+	keys := make([]string, 0, len(results))
+	for k := range results {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// loadChecks resolves which checks to run, in order of precedence: explicit
+// --check flags, a --policy file, or the registry default (all checks), and
+// returns any per-check score thresholds the policy file configured.
+// Explicit --check flags carry no thresholds, so every check falls back to
+// policy.DefaultThreshold.
+func loadChecks(policyFile string, explicit []string) ([]string, map[string]int, error) {
+	if len(explicit) > 0 {
+		return explicit, nil, nil
+	}
+	if policyFile == "" {
+		return nil, nil, nil
+	}
+	names, err := policy.LoadChecks(policyFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	thresholds, err := policy.LoadThresholds(policyFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	return names, thresholds, nil
+}
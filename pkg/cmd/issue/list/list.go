@@ -0,0 +1,453 @@
+package list
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/github/gh-cli/api"
+	"github.com/github/gh-cli/internal/config"
+	"github.com/github/gh-cli/internal/ghrepo"
+	"github.com/github/gh-cli/pkg/cmdutil"
+	"github.com/github/gh-cli/pkg/iostreams"
+	"github.com/github/gh-cli/utils"
+	"github.com/itchyny/gojq"
+	"github.com/spf13/cobra"
+)
+
+type ListOptions struct {
+	IO         *iostreams.IOStreams
+	HttpClient func() (*http.Client, error)
+	Config     func() (config.Config, error)
+	BaseRepo   func() (ghrepo.Interface, error)
+
+	WebMode bool
+	Limit   int
+
+	Assignee  string
+	Author    string
+	Labels    []string
+	State     string
+	Mention   string
+	Milestone string
+	Search    string
+
+	JSONFields []string
+	JQExpr     string
+	Template   string
+}
+
+// jsonFields is the full set of fields --json will accept.
+var jsonFields = []string{
+	"number", "title", "state", "labels", "assignees", "author",
+	"milestone", "createdAt", "updatedAt", "url", "body", "comments",
+}
+
+func NewCmdList(f *cmdutil.Factory, runF func(*ListOptions) error) *cobra.Command {
+	opts := &ListOptions{
+		IO:         f.IOStreams,
+		HttpClient: f.HttpClient,
+		Config:     f.Config,
+		BaseRepo:   f.BaseRepo,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List and filter issues in this repository",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.Limit < 1 {
+				return fmt.Errorf("invalid limit: %v", opts.Limit)
+			}
+
+			if runF != nil {
+				return runF(opts)
+			}
+			return listRun(opts)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opts.WebMode, "web", "w", false, "Open the list of issues in the web browser")
+	cmd.Flags().IntVarP(&opts.Limit, "limit", "L", 30, "Maximum number of issues to fetch")
+	cmd.Flags().StringVarP(&opts.Assignee, "assignee", "a", "", "Filter by assignee")
+	cmd.Flags().StringVarP(&opts.Author, "author", "A", "", "Filter by author")
+	cmd.Flags().StringSliceVarP(&opts.Labels, "label", "l", nil, "Filter by label")
+	cmd.Flags().StringVarP(&opts.State, "state", "s", "open", "Filter by state: {open|closed|all}")
+	cmd.Flags().StringVar(&opts.Mention, "mention", "", "Filter by mention")
+	cmd.Flags().StringVar(&opts.Milestone, "milestone", "", "Filter by milestone number or title")
+	cmd.Flags().StringVar(&opts.Search, "search", "", "Search issues with query")
+	cmd.Flags().StringSliceVar(&opts.JSONFields, "json", nil, fmt.Sprintf("Output JSON with the specified fields: %s", strings.Join(jsonFields, ",")))
+	cmd.Flags().StringVar(&opts.JQExpr, "jq", "", "Filter the JSON output using a jq expression")
+	cmd.Flags().StringVar(&opts.Template, "template", "", "Format the JSON output using a Go template")
+
+	return cmd
+}
+
+func listRun(opts *ListOptions) error {
+	if err := validateJSONFields(opts.JSONFields); err != nil {
+		return err
+	}
+
+	httpClient, err := opts.HttpClient()
+	if err != nil {
+		return err
+	}
+	requester := api.NewRequester(httpClient)
+
+	baseRepo, err := opts.BaseRepo()
+	if err != nil {
+		return err
+	}
+
+	if opts.WebMode {
+		openURL := buildIssuesURL(baseRepo, opts)
+		if opts.IO.IsStdoutTTY() {
+			fmt.Fprintf(opts.IO.ErrOut, "Opening %s in your browser.\n", displayURL(openURL))
+		}
+		return utils.OpenInBrowser(openURL)
+	}
+
+	assignee, author, mention, err := resolveAtMe(requester, baseRepo, opts)
+	if err != nil {
+		return err
+	}
+
+	milestone, err := resolveMilestone(requester, baseRepo, opts.Milestone)
+	if err != nil {
+		return err
+	}
+
+	fields := jsonFieldsOrDefault(opts.JSONFields)
+	states := issueStates(opts.State)
+
+	vars := map[string]interface{}{"states": states}
+	if assignee != "" {
+		vars["assignee"] = assignee
+	}
+	if author != "" {
+		vars["author"] = author
+	}
+	if mention != "" {
+		vars["mention"] = mention
+	}
+	if milestone != "" {
+		vars["milestone"] = milestone
+	}
+	if len(opts.Labels) > 0 {
+		vars["labels"] = opts.Labels
+	}
+
+	var result *api.IssuesAndTotalCount
+	if opts.Search != "" {
+		result, err = api.IssueSearch(requester, baseRepo, opts.Search, opts.Limit, fields)
+	} else {
+		result, err = api.IssueList(requester, baseRepo, vars, opts.Limit, fields)
+	}
+	if err != nil {
+		var rateLimitErr *api.RateLimitError
+		if errors.As(err, &rateLimitErr) {
+			return fmt.Errorf("exceeded GitHub's secondary rate limit while listing issues; try again in a bit: %w", err)
+		}
+		return err
+	}
+
+	if !result.HasIssuesEnabled {
+		return fmt.Errorf("the '%s' repository has disabled issues", ghrepo.FullName(baseRepo))
+	}
+
+	if len(opts.JSONFields) > 0 {
+		return renderJSON(opts, result.Issues)
+	}
+
+	return printIssues(opts, baseRepo, result)
+}
+
+// defaultFields is requested from the API when the caller didn't ask for
+// --json; it's the minimum needed to render the human/TSV table.
+var defaultFields = []string{"number", "title", "labels", "state", "updatedAt"}
+
+func jsonFieldsOrDefault(requested []string) []string {
+	if len(requested) > 0 {
+		return requested
+	}
+	return defaultFields
+}
+
+// validateJSONFields rejects any --json field name that issueJSONValue
+// doesn't know how to render; otherwise a typo silently produces a `null`
+// column instead of an error.
+func validateJSONFields(requested []string) error {
+	for _, field := range requested {
+		found := false
+		for _, known := range jsonFields {
+			if field == known {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("unknown JSON field: %q", field)
+		}
+	}
+	return nil
+}
+
+// resolveAtMe turns any "@me" shorthand among assignee/author/mention into the
+// authenticated user's login, making a single GraphQL round-trip at most.
+func resolveAtMe(requester *api.Requester, baseRepo ghrepo.Interface, opts *ListOptions) (assignee, author, mention string, err error) {
+	assignee, author, mention = opts.Assignee, opts.Author, opts.Mention
+	if assignee != "@me" && author != "@me" && mention != "@me" {
+		return
+	}
+
+	login, err := api.CurrentLoginName(requester, baseRepo.RepoHost())
+	if err != nil {
+		return "", "", "", fmt.Errorf("failed to resolve @me: %w", err)
+	}
+	if assignee == "@me" {
+		assignee = login
+	}
+	if author == "@me" {
+		author = login
+	}
+	if mention == "@me" {
+		mention = login
+	}
+	return
+}
+
+// resolveMilestone turns a milestone flag value (a title, or a repo-relative
+// number) into the database ID the issues connection's milestone filter
+// expects.
+func resolveMilestone(requester *api.Requester, baseRepo ghrepo.Interface, milestone string) (string, error) {
+	if milestone == "" {
+		return "", nil
+	}
+
+	var nodeID string
+	var err error
+	if number, numErr := strconv.Atoi(milestone); numErr == nil {
+		nodeID, err = api.RepositoryMilestoneByNumber(requester, baseRepo, number)
+	} else {
+		nodeID, err = api.RepositoryMilestoneList(requester, baseRepo, milestone)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return milestoneDatabaseID(nodeID)
+}
+
+// milestoneDatabaseID extracts the legacy numeric database ID encoded in a
+// milestone's GraphQL node ID (e.g. "MDk6TWlsZXN0b25lMTIzNDU=" decodes to
+// "09:Milestone12345"), which is what the issues connection's milestone
+// filter takes rather than the node ID itself.
+func milestoneDatabaseID(nodeID string) (string, error) {
+	decoded, err := base64.StdEncoding.DecodeString(nodeID)
+	if err != nil {
+		return "", fmt.Errorf("invalid milestone ID: %q", nodeID)
+	}
+	parts := strings.SplitN(string(decoded), "Milestone", 2)
+	if len(parts) != 2 {
+		return "", fmt.Errorf("invalid milestone ID: %q", nodeID)
+	}
+	return parts[1], nil
+}
+
+func issueStates(state string) []string {
+	switch state {
+	case "all":
+		return []string{"OPEN", "CLOSED"}
+	case "closed":
+		return []string{"CLOSED"}
+	default:
+		return []string{"OPEN"}
+	}
+}
+
+// buildIssuesURL assembles the github.com/OWNER/REPO/issues?q=... search URL
+// used by --web; unlike the GraphQL path it filters by the raw milestone
+// value the user passed, since the web UI's search syntax takes a title.
+func buildIssuesURL(repo ghrepo.Interface, opts *ListOptions) string {
+	q := "is:issue"
+	if opts.State != "all" {
+		q += fmt.Sprintf(" is:%s", opts.State)
+	}
+	if opts.Assignee != "" {
+		q += " assignee:" + opts.Assignee
+	}
+	for _, l := range opts.Labels {
+		q += " label:" + l
+	}
+	if opts.Author != "" {
+		q += " author:" + opts.Author
+	}
+	if opts.Mention != "" {
+		q += " mentions:" + opts.Mention
+	}
+	if opts.Milestone != "" {
+		q += " milestone:" + opts.Milestone
+	}
+	if opts.Search != "" {
+		q += " " + opts.Search
+	}
+
+	return fmt.Sprintf("%s?q=%s", ghrepo.GenerateRepoURL(repo, "issues"), url.QueryEscape(q))
+}
+
+// displayURL trims the scheme off a URL for friendlier terminal output.
+func displayURL(u string) string {
+	return strings.TrimPrefix(strings.TrimPrefix(u, "https://"), "http://")
+}
+
+func printIssues(opts *ListOptions, baseRepo ghrepo.Interface, result *api.IssuesAndTotalCount) error {
+	io := opts.IO
+	if len(result.Issues) == 0 {
+		if !io.IsStdoutTTY() {
+			return nil
+		}
+		fmt.Fprintf(io.Out, "\nNo issues match your search in %s\n\n", ghrepo.FullName(baseRepo))
+		return nil
+	}
+
+	if io.IsStdoutTTY() {
+		fmt.Fprintf(io.Out, "\nShowing %d of %d open issues in %s\n\n", len(result.Issues), result.TotalCount, ghrepo.FullName(baseRepo))
+	}
+
+	cs := io.ColorScheme()
+	tp := utils.NewTablePrinter(io)
+	for _, issue := range result.Issues {
+		labels := issueLabelList(issue)
+		if tp.IsTTY() {
+			tp.AddField(fmt.Sprintf("#%d", issue.Number), nil, cs.Bold)
+			tp.AddField(issue.Title, nil, nil)
+			tp.AddField(labelsForDisplay(labels), nil, cs.Gray)
+		} else {
+			tp.AddField(strconv.Itoa(issue.Number), nil, nil)
+			tp.AddField(issue.Title, nil, nil)
+			tp.AddField(strings.Join(labels, ", "), nil, nil)
+		}
+		tp.AddField(utils.FuzzyAgo(issue.UpdatedAt), nil, cs.Gray)
+		tp.EndRow()
+	}
+
+	return tp.Render()
+}
+
+func issueLabelList(issue api.Issue) []string {
+	names := make([]string, 0, len(issue.Labels.Nodes))
+	for _, l := range issue.Labels.Nodes {
+		names = append(names, l.Name)
+	}
+	return names
+}
+
+func labelsForDisplay(labels []string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("(%s)", strings.Join(labels, ", "))
+}
+
+// renderJSON writes the requested fields of issues to stdout, optionally
+// piping the result through a jq expression or a Go template.
+func renderJSON(opts *ListOptions, issues []api.Issue) error {
+	data := make([]map[string]interface{}, len(issues))
+	for i, issue := range issues {
+		row := make(map[string]interface{}, len(opts.JSONFields))
+		for _, field := range opts.JSONFields {
+			row[field] = issueJSONValue(issue, field)
+		}
+		data[i] = row
+	}
+
+	if opts.JQExpr != "" {
+		return filterWithJQ(opts.IO, data, opts.JQExpr)
+	}
+	if opts.Template != "" {
+		return renderTemplate(opts.IO, data, opts.Template)
+	}
+
+	enc := json.NewEncoder(opts.IO.Out)
+	return enc.Encode(data)
+}
+
+func issueJSONValue(issue api.Issue, field string) interface{} {
+	switch field {
+	case "number":
+		return issue.Number
+	case "title":
+		return issue.Title
+	case "state":
+		return issue.State
+	case "url":
+		return issue.URL
+	case "body":
+		return issue.Body
+	case "createdAt":
+		return issue.CreatedAt
+	case "updatedAt":
+		return issue.UpdatedAt
+	case "author":
+		return issue.Author.Login
+	case "labels":
+		return issueLabelList(issue)
+	case "assignees":
+		names := make([]string, 0, len(issue.Assignees.Nodes))
+		for _, a := range issue.Assignees.Nodes {
+			names = append(names, a.Login)
+		}
+		return names
+	case "milestone":
+		if issue.Milestone == nil {
+			return nil
+		}
+		return issue.Milestone.Title
+	case "comments":
+		return issue.Comments.TotalCount
+	default:
+		return nil
+	}
+}
+
+func filterWithJQ(io *iostreams.IOStreams, data []map[string]interface{}, expr string) error {
+	query, err := gojq.Parse(expr)
+	if err != nil {
+		return fmt.Errorf("invalid jq expression: %w", err)
+	}
+
+	input := make([]interface{}, len(data))
+	for i, row := range data {
+		input[i] = row
+	}
+
+	iter := query.Run(input)
+	enc := json.NewEncoder(io.Out)
+	for {
+		v, ok := iter.Next()
+		if !ok {
+			break
+		}
+		if err, ok := v.(error); ok {
+			return err
+		}
+		if err := enc.Encode(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func renderTemplate(io *iostreams.IOStreams, data []map[string]interface{}, tmplText string) error {
+	tmpl, err := template.New("issues").Parse(tmplText)
+	if err != nil {
+		return fmt.Errorf("invalid template: %w", err)
+	}
+	return tmpl.Execute(io.Out, data)
+}
@@ -10,13 +10,13 @@ import (
 	"testing"
 
 	"github.com/MakeNowJust/heredoc"
-	"github.com/cli/cli/internal/config"
-	"github.com/cli/cli/internal/ghrepo"
-	"github.com/cli/cli/internal/run"
-	"github.com/cli/cli/pkg/cmdutil"
-	"github.com/cli/cli/pkg/httpmock"
-	"github.com/cli/cli/pkg/iostreams"
-	"github.com/cli/cli/test"
+	"github.com/github/gh-cli/internal/config"
+	"github.com/github/gh-cli/internal/ghrepo"
+	"github.com/github/gh-cli/internal/run"
+	"github.com/github/gh-cli/pkg/cmdutil"
+	"github.com/github/gh-cli/pkg/httpmock"
+	"github.com/github/gh-cli/pkg/iostreams"
+	"github.com/github/gh-cli/test"
 	"github.com/google/shlex"
 	"github.com/stretchr/testify/assert"
 )
@@ -325,6 +325,93 @@ func TestIssueList_Search_tty(t *testing.T) {
 	`), out)
 }
 
+func TestIssueList_withJSON(t *testing.T) {
+	http := &httpmock.Registry{}
+	defer http.Verify(t)
+
+	http.Register(
+		httpmock.GraphQL(`query IssueList\b`),
+		httpmock.FileResponse("./fixtures/issueList.json"))
+
+	output, err := runCommand(http, false, `--json number,title`)
+	if err != nil {
+		t.Errorf("error running command `issue list`: %v", err)
+	}
+
+	assert.Equal(t, "", output.Stderr())
+	assert.JSONEq(t, `[
+		{"number": 1, "title": "number won"},
+		{"number": 2, "title": "number too"},
+		{"number": 4, "title": "number fore"}
+	]`, output.String())
+}
+
+func TestIssueList_withJSON_unknownField(t *testing.T) {
+	http := &httpmock.Registry{}
+	defer http.Verify(t)
+
+	_, err := runCommand(http, false, `--json number,bogus`)
+	if err == nil || err.Error() != `unknown JSON field: "bogus"` {
+		t.Errorf("error running command `issue list`: %v", err)
+	}
+}
+
+func TestIssueList_withJQ(t *testing.T) {
+	http := &httpmock.Registry{}
+	defer http.Verify(t)
+
+	http.Register(
+		httpmock.GraphQL(`query IssueList\b`),
+		httpmock.FileResponse("./fixtures/issueList.json"))
+
+	output, err := runCommand(http, false, `--json number --jq ".[].number"`)
+	if err != nil {
+		t.Errorf("error running command `issue list`: %v", err)
+	}
+
+	assert.Equal(t, "1\n2\n4\n", output.String())
+}
+
+func TestIssueList_withTemplate(t *testing.T) {
+	http := &httpmock.Registry{}
+	defer http.Verify(t)
+
+	http.Register(
+		httpmock.GraphQL(`query IssueList\b`),
+		httpmock.FileResponse("./fixtures/issueList.json"))
+
+	output, err := runCommand(http, false, `--json number --template "{{range .}}{{.number}} {{end}}"`)
+	if err != nil {
+		t.Errorf("error running command `issue list`: %v", err)
+	}
+
+	assert.Equal(t, "1 2 4 ", output.String())
+}
+
+func TestIssueList_withJSON_prunesGraphQLSelectionSet(t *testing.T) {
+	http := &httpmock.Registry{}
+	defer http.Verify(t)
+
+	http.Register(
+		httpmock.GraphQL(`query IssueList\b`),
+		httpmock.GraphQLQuery(`
+		{ "data": {	"repository": {
+			"hasIssuesEnabled": true,
+			"issues": { "nodes": [] }
+		} } }`, func(query string, _ map[string]interface{}) {
+			assert.Contains(t, query, "number")
+			assert.Contains(t, query, "title")
+			assert.NotContains(t, query, "assignees")
+			assert.NotContains(t, query, "milestone")
+			assert.NotContains(t, query, "statusCheckRollup")
+		}))
+
+	_, err := runCommand(http, true, `--json number,title`)
+	if err != nil {
+		t.Errorf("error running command `issue list`: %v", err)
+	}
+}
+
 func TestIssueList_Search_web(t *testing.T) {
 	http := &httpmock.Registry{}
 	defer http.Verify(t)
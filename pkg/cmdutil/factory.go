@@ -0,0 +1,26 @@
+package cmdutil
+
+import (
+	"net/http"
+
+	"github.com/github/gh-cli/git"
+	"github.com/github/gh-cli/internal/config"
+	"github.com/github/gh-cli/internal/ghrepo"
+	"github.com/github/gh-cli/pkg/iostreams"
+)
+
+// Browser opens a URL in the user's browser.
+type Browser interface {
+	Browse(string) error
+}
+
+// Factory wires together the dependencies every command needs: I/O, the
+// HTTP client, configuration, and base repository resolution.
+type Factory struct {
+	IOStreams  *iostreams.IOStreams
+	HttpClient func() (*http.Client, error)
+	Config     func() (config.Config, error)
+	Remotes    func() (git.RemoteSet, error)
+	BaseRepo   func() (ghrepo.Interface, error)
+	Browser    Browser
+}
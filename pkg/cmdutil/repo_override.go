@@ -0,0 +1,22 @@
+package cmdutil
+
+import (
+	"os"
+
+	"github.com/github/gh-cli/internal/ghrepo"
+)
+
+// OverrideBaseRepoFunc returns a BaseRepo resolver that prefers, in order, an
+// explicit --repo argument, the GH_REPO environment variable, and finally
+// falls through to the Factory's existing resolver.
+func OverrideBaseRepoFunc(f *Factory, argOverride string) func() (ghrepo.Interface, error) {
+	return func() (ghrepo.Interface, error) {
+		if argOverride != "" {
+			return ghrepo.FromFullName(argOverride)
+		}
+		if envOverride := os.Getenv("GH_REPO"); envOverride != "" {
+			return ghrepo.FromFullName(envOverride)
+		}
+		return f.BaseRepo()
+	}
+}
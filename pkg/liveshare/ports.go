@@ -3,7 +3,11 @@ package liveshare
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"math/rand"
+	"time"
 
 	"github.com/sourcegraph/jsonrpc2"
 )
@@ -36,10 +40,132 @@ type PortUpdate struct {
 	StatusCode  int            `json:"statusCode"`
 }
 
+// RetryPolicy configures how a retryable Live Share RPC is retried: how
+// many attempts, the exponential backoff between them, and an optional hook
+// for observing retries as they happen.
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+	// Jitter is the fraction (0-1) of the computed backoff added as random
+	// jitter, to avoid every client retrying in lockstep.
+	Jitter float64
+	// OnRetry, if set, is called before each retry with the 1-indexed
+	// attempt about to run and the error that caused it.
+	OnRetry func(attempt int, err error)
+}
+
+// backoff computes the delay before the given retry attempt (1-indexed: the
+// delay before attempt 2 is backoff(1)), capped at MaxBackoff.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := float64(p.InitialBackoff) * math.Pow(p.Multiplier, float64(attempt-1))
+	if p.MaxBackoff > 0 && d > float64(p.MaxBackoff) {
+		d = float64(p.MaxBackoff)
+	}
+	backoff := time.Duration(d)
+	if p.Jitter > 0 {
+		backoff += time.Duration(rand.Float64() * p.Jitter * float64(backoff))
+	}
+	return backoff
+}
+
+// defaultRetryPolicy is used for the port-sharing RPCs below. The host
+// occasionally drops a request outright (e.g. right after the underlying
+// connection reconnects), so they retry with backoff instead of surfacing a
+// one-off RPC error to the caller.
+var defaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    4,
+	InitialBackoff: 250 * time.Millisecond,
+	MaxBackoff:     5 * time.Second,
+	Multiplier:     2,
+	Jitter:         0.5,
+}
+
+// retryableStatusCodes are the PortNotification.StatusCode values worth
+// retrying; anything else (e.g. a 4xx rejecting the request outright) is
+// returned to the caller immediately instead of being retried.
+var retryableStatusCodes = map[int]bool{
+	0:   true, // no status code reported; assume transient
+	429: true,
+	502: true,
+	503: true,
+	504: true,
+}
+
+// withRetry runs fn, retrying per policy while ctx isn't done, fn keeps
+// failing, and shouldRetry (if non-nil) agrees the failure is transient.
+func withRetry(ctx context.Context, policy RetryPolicy, shouldRetry func(error) bool, fn func() error) error {
+	var lastErr error
+	for attempt := 1; attempt <= policy.MaxAttempts; attempt++ {
+		if attempt > 1 {
+			if policy.OnRetry != nil {
+				policy.OnRetry(attempt, lastErr)
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(policy.backoff(attempt - 1)):
+			}
+		}
+
+		err := fn()
+		if err == nil {
+			return nil
+		}
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return err
+		}
+		if shouldRetry != nil && !shouldRetry(err) {
+			return err
+		}
+		lastErr = err
+	}
+
+	return fmt.Errorf("failed after %d attempts: %w", policy.MaxAttempts, lastErr)
+}
+
+// portNotificationError wraps a failed PortNotification so shouldRetry
+// callbacks can inspect its StatusCode via errors.As.
+type portNotificationError struct {
+	notification PortNotification
+}
+
+func (e *portNotificationError) Error() string {
+	return fmt.Sprintf("error while starting port sharing: %s", e.notification.ErrorDetail)
+}
+
+// retryableSharingError reports whether err is worth retrying: a failed
+// PortNotification is retried only for a whitelisted StatusCode, while any
+// other error (e.g. a transport-level RPC failure) is assumed transient.
+func retryableSharingError(err error) bool {
+	var notifErr *portNotificationError
+	if errors.As(err, &notifErr) {
+		return retryableStatusCodes[notifErr.notification.StatusCode]
+	}
+	return true
+}
+
 // startSharing tells the Live Share host to start sharing the specified port from the container.
 // The sessionName describes the purpose of the remote port or service.
 // It returns an identifier that can be used to open an SSH channel to the remote port.
 func (s *Session) startSharing(ctx context.Context, sessionName string, port int) (channelID, error) {
+	var id channelID
+	err := withRetry(ctx, defaultRetryPolicy, retryableSharingError, func() error {
+		var err error
+		id, err = s.startSharingOnce(ctx, sessionName, port)
+		return err
+	})
+	if err != nil {
+		return channelID{}, fmt.Errorf("failed to start sharing port %d: %w", port, err)
+	}
+	return id, nil
+}
+
+// startSharingOnce performs a single attempt at the share handshake: it asks
+// the host to start sharing port, then waits for the corresponding
+// sharingSucceeded/sharingFailed notification.
+func (s *Session) startSharingOnce(ctx context.Context, sessionName string, port int) (channelID, error) {
 	args := []interface{}{port, sessionName, fmt.Sprintf("http://localhost:%d", port)}
 	errc := make(chan error, 1)
 
@@ -50,7 +176,7 @@ func (s *Session) startSharing(ctx context.Context, sessionName string, port int
 			return
 		}
 		if !startNotification.Success {
-			errc <- fmt.Errorf("error while starting port sharing: %s", startNotification.ErrorDetail)
+			errc <- &portNotificationError{*startNotification}
 			return
 		}
 		errc <- nil // success
@@ -117,7 +243,10 @@ func (s *Session) WaitForPortNotification(ctx context.Context, port int, notifTy
 // shared by a prior call to StartSharing by some client.
 func (s *Session) GetSharedServers(ctx context.Context) ([]*Port, error) {
 	var response []*Port
-	if err := s.rpc.do(ctx, "serverSharing.getSharedServers", []string{}, &response); err != nil {
+	err := withRetry(ctx, defaultRetryPolicy, nil, func() error {
+		return s.rpc.do(ctx, "serverSharing.getSharedServers", []string{}, &response)
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -127,9 +256,7 @@ func (s *Session) GetSharedServers(ctx context.Context) ([]*Port, error) {
 // UpdateSharedServerPrivacy controls port permissions and visibility scopes for who can access its URLs
 // in the browser.
 func (s *Session) UpdateSharedServerPrivacy(ctx context.Context, port int, visibility string) error {
-	if err := s.rpc.do(ctx, "serverSharing.updateSharedServerPrivacy", []interface{}{port, visibility}, nil); err != nil {
-		return err
-	}
-
-	return nil
+	return withRetry(ctx, defaultRetryPolicy, nil, func() error {
+		return s.rpc.do(ctx, "serverSharing.updateSharedServerPrivacy", []interface{}{port, visibility}, nil)
+	})
 }
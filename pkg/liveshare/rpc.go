@@ -0,0 +1,222 @@
+package liveshare
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/sourcegraph/jsonrpc2"
+)
+
+// MethodHandler answers a single inbound JSON-RPC request from the Live
+// Share host, returning the result to send back (or an error, which is
+// sent back as a JSON-RPC error response).
+type MethodHandler func(ctx context.Context, params json.RawMessage) (interface{}, error)
+
+type rpcClient struct {
+	*jsonrpc2.Conn
+	conn    io.ReadWriteCloser
+	handler *rpcHandler
+}
+
+func newRpcClient(conn io.ReadWriteCloser) *rpcClient {
+	return &rpcClient{conn: conn, handler: newRPCHandler()}
+}
+
+func (r *rpcClient) connect(ctx context.Context) {
+	stream := jsonrpc2.NewBufferedStream(r.conn, jsonrpc2.VSCodeObjectCodec{})
+	// TODO(adonovan): fix: ensure r.Conn is eventually Closed!
+	r.Conn = jsonrpc2.NewConn(ctx, stream, r.handler)
+}
+
+func (r *rpcClient) do(ctx context.Context, method string, args interface{}, result interface{}) error {
+	waiter, err := r.Conn.DispatchCall(ctx, method, args)
+	if err != nil {
+		return fmt.Errorf("error on dispatch call: %v", err)
+	}
+
+	return waiter.Wait(ctx, result)
+}
+
+// RegisterMethod registers handler to answer inbound requests for method,
+// enabling callbacks the Live Share protocol expects from the client side
+// (e.g. workspace.shareRequest) that a purely outbound rpcClient can't
+// support on its own.
+func (r *rpcClient) RegisterMethod(method string, handler MethodHandler) {
+	r.handler.registerMethod(method, handler)
+}
+
+// OverflowPolicy selects what happens when a subscriber's buffered channel
+// is full and another notification arrives for its method.
+type OverflowPolicy int
+
+const (
+	// DropNewest discards the incoming notification, keeping what's already
+	// buffered.
+	DropNewest OverflowPolicy = iota
+	// DropOldest discards the oldest buffered notification to make room for
+	// the incoming one.
+	DropOldest
+)
+
+// subscriberBuffer bounds how many unconsumed notifications a subscriber
+// can accumulate before its overflow policy kicks in.
+const subscriberBuffer = 16
+
+type eventSubscriber struct {
+	ch     chan *jsonrpc2.Request
+	policy OverflowPolicy
+}
+
+type rpcHandler struct {
+	mutex       sync.RWMutex
+	subscribers map[string][]*eventSubscriber
+	wildcard    []*eventSubscriber
+	methods     map[string]MethodHandler
+}
+
+func newRPCHandler() *rpcHandler {
+	return &rpcHandler{
+		subscribers: make(map[string][]*eventSubscriber),
+		methods:     make(map[string]MethodHandler),
+	}
+}
+
+// registerMethod registers handler to answer inbound requests for method.
+func (r *rpcHandler) registerMethod(method string, handler MethodHandler) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.methods[method] = handler
+}
+
+// Subscribe registers interest in notifications for eventMethod, returning a
+// channel that receives them and a cancel function to unsubscribe. Unlike
+// registerEventHandler, the subscription stays live across every matching
+// notification until cancel is called, rather than being torn down after
+// the first dispatch.
+func (r *rpcHandler) Subscribe(eventMethod string, policy OverflowPolicy) (<-chan *jsonrpc2.Request, func()) {
+	sub := &eventSubscriber{ch: make(chan *jsonrpc2.Request, subscriberBuffer), policy: policy}
+
+	r.mutex.Lock()
+	r.subscribers[eventMethod] = append(r.subscribers[eventMethod], sub)
+	r.mutex.Unlock()
+
+	return sub.ch, func() { r.unsubscribe(eventMethod, sub) }
+}
+
+// SubscribeAll registers interest in every notification regardless of
+// method, for debugging.
+func (r *rpcHandler) SubscribeAll(policy OverflowPolicy) (<-chan *jsonrpc2.Request, func()) {
+	sub := &eventSubscriber{ch: make(chan *jsonrpc2.Request, subscriberBuffer), policy: policy}
+
+	r.mutex.Lock()
+	r.wildcard = append(r.wildcard, sub)
+	r.mutex.Unlock()
+
+	return sub.ch, func() { r.unsubscribeWildcard(sub) }
+}
+
+func (r *rpcHandler) unsubscribe(eventMethod string, sub *eventSubscriber) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	subs := r.subscribers[eventMethod]
+	for i, s := range subs {
+		if s == sub {
+			r.subscribers[eventMethod] = append(subs[:i], subs[i+1:]...)
+			return
+		}
+	}
+}
+
+func (r *rpcHandler) unsubscribeWildcard(sub *eventSubscriber) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for i, s := range r.wildcard {
+		if s == sub {
+			r.wildcard = append(r.wildcard[:i], r.wildcard[i+1:]...)
+			return
+		}
+	}
+}
+
+// Handle dispatches an inbound notification to every subscriber registered
+// for req.Method plus every wildcard subscriber. It only takes a read lock,
+// so concurrent notifications for different methods aren't serialized
+// behind a single registration mutex.
+//
+// Requests that expect a reply (req.Notif == false) are routed instead to
+// the MethodHandler registered for req.Method, answering the host's call
+// via conn.Reply or conn.ReplyWithError rather than fanning out to
+// subscribers, since only one response can be sent per request.
+func (r *rpcHandler) Handle(ctx context.Context, conn *jsonrpc2.Conn, req *jsonrpc2.Request) {
+	if !req.Notif {
+		r.handleRequest(ctx, conn, req)
+		return
+	}
+
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	for _, sub := range r.subscribers[req.Method] {
+		deliver(sub, req)
+	}
+	for _, sub := range r.wildcard {
+		deliver(sub, req)
+	}
+}
+
+func (r *rpcHandler) handleRequest(ctx context.Context, conn *jsonrpc2.Conn, req *jsonrpc2.Request) {
+	r.mutex.RLock()
+	handler, ok := r.methods[req.Method]
+	r.mutex.RUnlock()
+
+	if !ok {
+		_ = conn.ReplyWithError(ctx, req.ID, &jsonrpc2.Error{
+			Code:    jsonrpc2.CodeMethodNotFound,
+			Message: fmt.Sprintf("no handler registered for method %q", req.Method),
+		})
+		return
+	}
+
+	var params json.RawMessage
+	if req.Params != nil {
+		params = json.RawMessage(*req.Params)
+	}
+
+	result, err := handler(ctx, params)
+	if err != nil {
+		_ = conn.ReplyWithError(ctx, req.ID, &jsonrpc2.Error{
+			Code:    jsonrpc2.CodeInternalError,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	_ = conn.Reply(ctx, req.ID, result)
+}
+
+// deliver sends req to sub's channel without blocking, applying sub's
+// overflow policy if the buffer is already full.
+func deliver(sub *eventSubscriber, req *jsonrpc2.Request) {
+	select {
+	case sub.ch <- req:
+		return
+	default:
+	}
+
+	switch sub.policy {
+	case DropOldest:
+		select {
+		case <-sub.ch:
+		default:
+		}
+		select {
+		case sub.ch <- req:
+		default:
+		}
+	case DropNewest:
+		// leave the buffer as-is, dropping req
+	}
+}
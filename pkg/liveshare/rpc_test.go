@@ -0,0 +1,115 @@
+package liveshare
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"testing"
+
+	"github.com/sourcegraph/jsonrpc2"
+)
+
+func TestRPCHandler_SubscribeReceivesMultipleNotifications(t *testing.T) {
+	h := newRPCHandler()
+	ch, cancel := h.Subscribe("serverSharing.sharingStarted", DropNewest)
+	defer cancel()
+
+	ctx := context.Background()
+	h.Handle(ctx, nil, &jsonrpc2.Request{Method: "serverSharing.sharingStarted"})
+	h.Handle(ctx, nil, &jsonrpc2.Request{Method: "serverSharing.sharingStarted"})
+
+	if len(ch) != 2 {
+		t.Fatalf("expected 2 buffered notifications, got %d", len(ch))
+	}
+}
+
+func TestRPCHandler_UnsubscribeStopsDelivery(t *testing.T) {
+	h := newRPCHandler()
+	ch, cancel := h.Subscribe("serverSharing.sharingStarted", DropNewest)
+	cancel()
+
+	h.Handle(context.Background(), nil, &jsonrpc2.Request{Method: "serverSharing.sharingStarted"})
+
+	if len(ch) != 0 {
+		t.Fatalf("expected no notifications after unsubscribe, got %d", len(ch))
+	}
+}
+
+func TestRPCHandler_SubscribeAllReceivesEveryMethod(t *testing.T) {
+	h := newRPCHandler()
+	ch, cancel := h.SubscribeAll(DropNewest)
+	defer cancel()
+
+	ctx := context.Background()
+	h.Handle(ctx, nil, &jsonrpc2.Request{Method: "serverSharing.sharingStarted"})
+	h.Handle(ctx, nil, &jsonrpc2.Request{Method: "serverSharing.sharingFailed"})
+
+	if len(ch) != 2 {
+		t.Fatalf("expected 2 buffered notifications, got %d", len(ch))
+	}
+}
+
+func TestRPCHandler_DropOldestMakesRoomForNewest(t *testing.T) {
+	h := newRPCHandler()
+	ch, cancel := h.Subscribe("m", DropOldest)
+	defer cancel()
+
+	ctx := context.Background()
+	for i := 0; i < subscriberBuffer+1; i++ {
+		h.Handle(ctx, nil, &jsonrpc2.Request{Method: "m"})
+	}
+
+	if len(ch) != subscriberBuffer {
+		t.Fatalf("expected buffer to stay at capacity %d, got %d", subscriberBuffer, len(ch))
+	}
+}
+
+func TestRPCHandler_DropNewestDiscardsWhenFull(t *testing.T) {
+	h := newRPCHandler()
+	ch, cancel := h.Subscribe("m", DropNewest)
+	defer cancel()
+
+	ctx := context.Background()
+	for i := 0; i < subscriberBuffer+5; i++ {
+		h.Handle(ctx, nil, &jsonrpc2.Request{Method: "m"})
+	}
+
+	if len(ch) != subscriberBuffer {
+		t.Fatalf("expected buffer to stay at capacity %d, got %d", subscriberBuffer, len(ch))
+	}
+}
+
+func TestRPCClient_RegisterMethod_roundTrip(t *testing.T) {
+	serverConn, clientConn := net.Pipe()
+
+	client := newRpcClient(clientConn)
+	client.connect(context.Background())
+	defer client.Close()
+
+	client.RegisterMethod("workspace.shareRequest", func(ctx context.Context, params json.RawMessage) (interface{}, error) {
+		var args struct {
+			Name string `json:"name"`
+		}
+		if err := json.Unmarshal(params, &args); err != nil {
+			return nil, err
+		}
+		return map[string]string{"accepted": args.Name}, nil
+	})
+
+	serverStream := jsonrpc2.NewBufferedStream(serverConn, jsonrpc2.VSCodeObjectCodec{})
+	server := jsonrpc2.NewConn(context.Background(), serverStream, jsonrpc2.HandlerWithError(
+		func(ctx context.Context, conn *jsonrpc2.Conn, req *jsonrpc2.Request) (interface{}, error) {
+			return nil, nil
+		},
+	))
+	defer server.Close()
+
+	var result map[string]string
+	if err := server.Call(context.Background(), "workspace.shareRequest", map[string]string{"name": "port-forward"}, &result); err != nil {
+		t.Fatalf("Call() returned error: %v", err)
+	}
+
+	if result["accepted"] != "port-forward" {
+		t.Errorf("expected accepted %q, got %q", "port-forward", result["accepted"])
+	}
+}
@@ -0,0 +1,135 @@
+package policy
+
+import (
+	"context"
+	"regexp"
+	"unicode/utf8"
+)
+
+func defaultChecks() []Check {
+	return []Check{
+		secretsCheck{},
+		filenameCheck{},
+		fileSizeCheck{},
+		licenseCheck{},
+	}
+}
+
+// maxFileSize is the threshold above which fileSizeCheck flags a file as oversized.
+const maxFileSize = 1 << 20 // 1 MiB
+
+var secretPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)-----BEGIN (RSA|OPENSSH|EC|DSA|PGP) PRIVATE KEY-----`),
+	regexp.MustCompile(`(?i)\bghp_[a-zA-Z0-9]{36}\b`),
+	regexp.MustCompile(`(?i)\baws_secret_access_key\s*=\s*\S+`),
+}
+
+type secretsCheck struct{}
+
+func (secretsCheck) Name() string { return "secrets" }
+
+func (secretsCheck) Run(ctx context.Context, target *Target) (Result, error) {
+	result := Result{Score: 100}
+	for name, content := range target.Files {
+		for _, pattern := range secretPatterns {
+			if pattern.MatchString(content) {
+				result.Findings = append(result.Findings, Finding{
+					Check:       "secrets",
+					Severity:    SeverityError,
+					File:        name,
+					Message:     "file appears to contain a secret or private key",
+					Remediation: "remove the secret and rotate it before sharing this gist",
+				})
+				result.Score = 0
+			}
+		}
+	}
+	return result, nil
+}
+
+type filenameCheck struct{}
+
+var invalidFilenameChars = regexp.MustCompile(`[/\\]`)
+
+func (filenameCheck) Name() string { return "filename" }
+
+func (filenameCheck) Run(ctx context.Context, target *Target) (Result, error) {
+	result := Result{Score: 100}
+	for name := range target.Files {
+		if name == "" {
+			result.Findings = append(result.Findings, Finding{
+				Check: "filename", Severity: SeverityError, Message: "filename cannot be blank",
+			})
+			result.Score = 0
+			continue
+		}
+		if invalidFilenameChars.MatchString(name) {
+			result.Findings = append(result.Findings, Finding{
+				Check:       "filename",
+				Severity:    SeverityError,
+				File:        name,
+				Message:     "gist filenames cannot contain path separators",
+				Remediation: "rename the file to a flat name without slashes",
+			})
+			result.Score = 0
+		}
+	}
+	return result, nil
+}
+
+type fileSizeCheck struct{}
+
+func (fileSizeCheck) Name() string { return "file-size" }
+
+func (fileSizeCheck) Run(ctx context.Context, target *Target) (Result, error) {
+	result := Result{Score: 100}
+	for name, content := range target.Files {
+		if len(content) > maxFileSize {
+			result.Findings = append(result.Findings, Finding{
+				Check:       "file-size",
+				Severity:    SeverityWarn,
+				File:        name,
+				Message:     "file exceeds the recommended 1 MiB limit for gists",
+				Remediation: "split large files or host them elsewhere",
+			})
+			result.Score -= 25
+		}
+		if !utf8.ValidString(content) {
+			result.Findings = append(result.Findings, Finding{
+				Check:    "file-size",
+				Severity: SeverityWarn,
+				File:     name,
+				Message:  "file appears to be binary content",
+			})
+			result.Score -= 25
+		}
+	}
+	if result.Score < 0 {
+		result.Score = 0
+	}
+	return result, nil
+}
+
+type licenseCheck struct{}
+
+func (licenseCheck) Name() string { return "license" }
+
+func (licenseCheck) Run(ctx context.Context, target *Target) (Result, error) {
+	result := Result{Score: 100}
+	if len(target.Files) <= 1 {
+		return result, nil
+	}
+	for name := range target.Files {
+		if name == "LICENSE" || name == "LICENSE.md" || name == "LICENSE.txt" {
+			return result, nil
+		}
+	}
+	result.Findings = append(result.Findings, Finding{
+		Check:       "license",
+		Severity:    SeverityInfo,
+		Message:     "multi-file gist has no LICENSE file",
+		Remediation: "add a LICENSE file to clarify reuse terms",
+	})
+	result.Score = 75
+	return result, nil
+}
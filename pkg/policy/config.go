@@ -0,0 +1,61 @@
+package policy
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileConfig is the shape of a --policy <file.yaml> document: a list of
+// check names to run, each with an optional score threshold below which the
+// check is treated as failing.
+type FileConfig struct {
+	Checks []struct {
+		Name      string `yaml:"name"`
+		Threshold int    `yaml:"threshold"`
+	} `yaml:"checks"`
+}
+
+// LoadChecks reads a policy file and returns the list of check names it
+// selects, in file order.
+func LoadChecks(path string) ([]string, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read policy file: %w", err)
+	}
+
+	var cfg FileConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("could not parse policy file: %w", err)
+	}
+
+	names := make([]string, 0, len(cfg.Checks))
+	for _, c := range cfg.Checks {
+		names = append(names, c.Name)
+	}
+	return names, nil
+}
+
+// LoadThresholds reads a policy file and returns each check's configured
+// score threshold, keyed by check name. A check with no threshold set (or
+// set to zero) is omitted; callers should fall back to DefaultThreshold.
+func LoadThresholds(path string) (map[string]int, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read policy file: %w", err)
+	}
+
+	var cfg FileConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("could not parse policy file: %w", err)
+	}
+
+	thresholds := make(map[string]int, len(cfg.Checks))
+	for _, c := range cfg.Checks {
+		if c.Threshold > 0 {
+			thresholds[c.Name] = c.Threshold
+		}
+	}
+	return thresholds, nil
+}
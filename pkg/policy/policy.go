@@ -0,0 +1,110 @@
+// Package policy implements a pluggable checks-based scorecard for linting
+// gists and repos before they are submitted.
+package policy
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// Severity classifies how serious a Finding is.
+type Severity string
+
+const (
+	SeverityInfo  Severity = "info"
+	SeverityWarn  Severity = "warn"
+	SeverityError Severity = "error"
+)
+
+// Finding is a single issue raised by a Check.
+type Finding struct {
+	Check       string   `json:"check"`
+	Severity    Severity `json:"severity"`
+	Message     string   `json:"message"`
+	File        string   `json:"file,omitempty"`
+	Remediation string   `json:"remediation,omitempty"`
+}
+
+// Result is what a Check returns after inspecting a Target.
+type Result struct {
+	Score       int       `json:"score"`
+	Findings    []Finding `json:"findings"`
+	Remediation string    `json:"remediation,omitempty"`
+}
+
+// DefaultThreshold is the score a check must meet or exceed to pass when a
+// policy file doesn't set an explicit threshold for it. It matches the
+// score every built-in check starts from, so "no threshold configured"
+// means "any finding fails the check".
+const DefaultThreshold = 100
+
+// Target is the set of files a Check inspects. It's intentionally generic so
+// the same checks can run against a gist's working file set or a repo tree.
+type Target struct {
+	Files map[string]string // filename -> content
+}
+
+// Check is a single pluggable policy rule.
+type Check interface {
+	Name() string
+	Run(ctx context.Context, target *Target) (Result, error)
+}
+
+// Registry holds the set of checks that `gh gist lint`/`gh repo lint` run.
+// External `gh` extensions can call Register from their own init() to
+// participate in the same lint pass.
+type Registry struct {
+	checks map[string]Check
+}
+
+// NewRegistry returns a Registry with the built-in checks already registered.
+func NewRegistry() *Registry {
+	r := &Registry{checks: map[string]Check{}}
+	for _, c := range defaultChecks() {
+		r.Register(c)
+	}
+	return r
+}
+
+// Register adds a Check to the registry, replacing any existing check with
+// the same name.
+func (r *Registry) Register(c Check) {
+	if r.checks == nil {
+		r.checks = map[string]Check{}
+	}
+	r.checks[c.Name()] = c
+}
+
+// Names returns the registered check names in sorted order.
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.checks))
+	for name := range r.checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Run executes the named checks (or all registered checks if names is empty)
+// against target, in a stable order.
+func (r *Registry) Run(ctx context.Context, target *Target, names []string) (map[string]Result, error) {
+	if len(names) == 0 {
+		names = r.Names()
+	}
+
+	results := make(map[string]Result, len(names))
+	for _, name := range names {
+		check, ok := r.checks[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown policy check %q", name)
+		}
+		result, err := check.Run(ctx, target)
+		if err != nil {
+			return nil, fmt.Errorf("check %q: %w", name, err)
+		}
+		results[name] = result
+	}
+
+	return results, nil
+}
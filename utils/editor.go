@@ -0,0 +1,54 @@
+package utils
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+)
+
+// EditorCommand resolves the command to launch for an interactively-authored
+// scratch file, preferring $GIT_EDITOR (matching git's own precedence) over
+// $EDITOR, and falling back to "vi" if neither is set.
+func EditorCommand() string {
+	if e := os.Getenv("GIT_EDITOR"); e != "" {
+		return e
+	}
+	if e := os.Getenv("EDITOR"); e != "" {
+		return e
+	}
+	return "vi"
+}
+
+// EditFile writes initial to a scratch file matching pattern (see
+// ioutil.TempFile), opens it in the editor returned by EditorCommand, and
+// returns the file's contents once the editor exits.
+func EditFile(pattern, initial string) (string, error) {
+	f, err := ioutil.TempFile("", pattern)
+	if err != nil {
+		return "", fmt.Errorf("failed to create scratch file: %w", err)
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.WriteString(initial); err != nil {
+		f.Close()
+		return "", fmt.Errorf("failed to write scratch file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return "", fmt.Errorf("failed to close scratch file: %w", err)
+	}
+
+	cmd := exec.Command("sh", "-c", fmt.Sprintf("%s %q", EditorCommand(), f.Name()))
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("failed to run editor: %w", err)
+	}
+
+	edited, err := ioutil.ReadFile(f.Name())
+	if err != nil {
+		return "", fmt.Errorf("failed to read scratch file: %w", err)
+	}
+	return string(edited), nil
+}
@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"io"
+	"os"
+	"os/exec"
+)
+
+// defaultPagerCommand is used when neither $GH_PAGER nor $PAGER is set at
+// all; -F exits immediately for output that fits on one screen, -R lets our
+// ANSI color codes through, and -X avoids clearing the screen on exit.
+const defaultPagerCommand = "less -FRX"
+
+// PagerCommand resolves the command to pipe long output through, preferring
+// $GH_PAGER over $PAGER, mirroring git's own $GIT_PAGER/$PAGER precedence,
+// and falling back to less(1) if neither is set. Explicitly setting either
+// variable to an empty string disables paging, distinct from leaving it
+// unset.
+func PagerCommand() string {
+	if p, ok := os.LookupEnv("GH_PAGER"); ok {
+		return p
+	}
+	if p, ok := os.LookupEnv("PAGER"); ok {
+		return p
+	}
+	return defaultPagerCommand
+}
+
+// RunPager writes content to out, piping it through the command returned by
+// PagerCommand when one is configured (and not the explicit no-op "cat") so
+// the pager can take over the terminal; otherwise it writes directly.
+func RunPager(out io.Writer, content string) error {
+	pagerCmd := PagerCommand()
+	if pagerCmd == "" || pagerCmd == "cat" {
+		_, err := io.WriteString(out, content)
+		return err
+	}
+
+	cmd := exec.Command("sh", "-c", pagerCmd)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	// The pager may exit (and close its stdin) before reading everything,
+	// e.g. because the user quit early; that's not our error to report.
+	io.WriteString(stdin, content)
+	stdin.Close()
+
+	return cmd.Wait()
+}